@@ -1,25 +1,25 @@
 package main
 
 import (
-	"context"
 	"dullahan/config"
 	"embed"
 	"net/http"
-	"time"
 
+	"dullahan/internal/api/v1/admin/exchangerate"
 	"dullahan/internal/api/v1/auth"
 	"dullahan/internal/api/v1/customer/debt"
 	"dullahan/internal/api/v1/customer/expense"
+	"dullahan/internal/api/v1/customer/goal"
 	"dullahan/internal/api/v1/customer/income"
 	"dullahan/internal/api/v1/customer/session"
 	"dullahan/internal/db"
+	"dullahan/internal/money"
 	"dullahan/internal/rbac"
 	"dullahan/internal/util/crypter"
 	dbutil "dullahan/internal/util/db"
 
 	"github.com/M15t/ghoul/pkg/server"
 	"github.com/M15t/ghoul/pkg/server/middleware/jwt"
-	"github.com/allegro/bigcache/v3"
 	"github.com/labstack/echo/v4"
 
 	_ "dullahan/internal/util/swagger" // Swagger stuffs
@@ -34,6 +34,7 @@ var (
 func main() {
 	cfg, err := config.Load()
 	checkErr(err)
+	checkErr(money.SetRoundingMode(cfg.RoundingMode))
 
 	gdb, err := dbutil.New(cfg.DbDsn, cfg.DbLog)
 	checkErr(err)
@@ -72,15 +73,14 @@ func main() {
 	crypterSvc := crypter.New()
 	jwtSvc := jwt.New(cfg.JwtAlgorithm, cfg.JwtSecret, cfg.JwtDuration)
 
-	cache, _ := bigcache.New(context.Background(), bigcache.DefaultConfig(1*time.Minute))
-	defer cache.Reset()
-
 	authSvc := auth.New(dbSvc, jwtSvc, crypterSvc, cfg)
 
 	incomeSvc := income.New(dbSvc, rbacSvc, crypterSvc)
 	expenseSvc := expense.New(dbSvc, rbacSvc, crypterSvc)
 	debtSvc := debt.New(dbSvc, rbacSvc, crypterSvc)
-	sessionSvc := session.New(dbSvc, rbacSvc, crypterSvc, cache)
+	goalSvc := goal.New(dbSvc, rbacSvc, crypterSvc)
+	sessionSvc := session.New(dbSvc, rbacSvc, crypterSvc)
+	exchangeRateSvc := exchangerate.New(dbSvc, rbacSvc)
 
 	// * Initialize v1 API
 	v1Router := e.Group("/v1")
@@ -95,8 +95,14 @@ func main() {
 	income.NewHTTP(incomeSvc, authSvc, v1cRouter.Group("/incomes"))
 	expense.NewHTTP(expenseSvc, authSvc, v1cRouter.Group("/expenses"))
 	debt.NewHTTP(debtSvc, authSvc, v1cRouter.Group("/debts"))
+	goal.NewHTTP(goalSvc, authSvc, v1cRouter.Group("/goals"))
 	session.NewHTTP(sessionSvc, authSvc, v1cRouter.Group("/me"))
 
+	v1aRouter := v1Router.Group("/admin")
+	v1aRouter.Use(jwtSvc.MWFunc())
+
+	exchangerate.NewHTTP(exchangeRateSvc, authSvc, v1aRouter.Group("/exchange-rates"))
+
 	// Start the HTTP server
 	server.Start(e, cfg.Stage == "development")
 }
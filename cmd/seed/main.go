@@ -2,12 +2,14 @@ package main
 
 import (
 	"dullahan/config"
+	"dullahan/internal/currency"
 	"encoding/json"
 	"os"
 	"time"
 
 	"dullahan/internal/db"
 	"dullahan/internal/model"
+	"dullahan/internal/money"
 	dbutil "dullahan/internal/util/db"
 
 	"gorm.io/datatypes"
@@ -46,6 +48,11 @@ func main() {
 	if err := migrateExpense(dbSvc); err != nil {
 		checkErr(err)
 	}
+
+	// * exchange rate
+	if err := migrateExchangeRate(dbSvc); err != nil {
+		checkErr(err)
+	}
 }
 
 func checkErr(err error) {
@@ -77,26 +84,26 @@ type (
 		RefreshToken string               `json:"-" gorm:"type:varchar(100);unique_index"`
 		LastLogin    *postgreSQLTimestamp `json:"last_login"`
 
-		TotalAllIncome           float64 `json:"total_all_income"`
-		TotalAllExpense          float64 `json:"total_all_expense"`
-		TotalMonthlyPaymentDebt  float64 `json:"total_monthly_payment_debt"`
-		TotalEssentialExpense    float64 `json:"total_essential_expense"`
-		TotalNonEssentialExpense float64 `json:"total_non_essential_expense"`
-		MonthlyNetFlow           float64 `json:"monthly_net_flow"` // important
+		TotalAllIncome           money.Decimal `json:"total_all_income"`
+		TotalAllExpense          money.Decimal `json:"total_all_expense"`
+		TotalMonthlyPaymentDebt  money.Decimal `json:"total_monthly_payment_debt"`
+		TotalEssentialExpense    money.Decimal `json:"total_essential_expense"`
+		TotalNonEssentialExpense money.Decimal `json:"total_non_essential_expense"`
+		MonthlyNetFlow           money.Decimal `json:"monthly_net_flow"` // important
 
-		CurrentBalance float64 `json:"current_balance"`
+		CurrentBalance money.Decimal `json:"current_balance"`
 
-		ActualEmergencyFund   float64 `json:"actual_emergency_fund"`
-		ExpectedEmergencyFund float64 `json:"expected_emergency_fund"`
+		ActualEmergencyFund   money.Decimal `json:"actual_emergency_fund"`
+		ExpectedEmergencyFund money.Decimal `json:"expected_emergency_fund"`
 
-		ActualRainydayFund   float64 `json:"actual_rainyday_fund"`
-		ExpectedRainydayFund float64 `json:"expected_rainyday_fund"`
+		ActualRainydayFund   money.Decimal `json:"actual_rainyday_fund"`
+		ExpectedRainydayFund money.Decimal `json:"expected_rainyday_fund"`
 
-		ActualFunFund   float64 `json:"actual_fun_fund"`
-		ExpectedFunFund float64 `json:"expected_fun_fund"`
+		ActualFunFund   money.Decimal `json:"actual_fun_fund"`
+		ExpectedFunFund money.Decimal `json:"expected_fun_fund"`
 
-		Investment     float64 `json:"investment"`
-		RetirementPlan float64 `json:"retirement_plan"`
+		Investment     money.Decimal `json:"investment"`
+		RetirementPlan money.Decimal `json:"retirement_plan"`
 
 		IsAchivedEmergencyFund  customBool `json:"is_achived_emergency_fund"`
 		IsAchivedRainydayFund   customBool `json:"is_achived_rainyday_fund"`
@@ -119,9 +126,9 @@ type (
 		UpdatedAt postgreSQLTimestamp `json:"-"`
 		SessionID int64               `json:"session_id"`
 
-		Amount float64 `json:"amount"`
-		Name   string  `json:"name" gorm:"type:varchar(100)"`
-		Type   string  `json:"type" gorm:"type:varchar(10);default:MONTHLY"` // MONTHLY, PASSIVE
+		Amount money.Decimal `json:"amount"`
+		Name   string        `json:"name" gorm:"type:varchar(100)"`
+		Type   string        `json:"type" gorm:"type:varchar(10);default:MONTHLY"` // MONTHLY, PASSIVE
 	}
 
 	tmpDebt struct {
@@ -131,8 +138,8 @@ type (
 		SessionID int64               `json:"session_id"`
 
 		Name            string         `json:"name" gorm:"type:varchar(50)"`
-		RemainingAmount float64        `json:"remaining_amount"`
-		MonthlyPayment  float64        `json:"monthly_payment"`
+		RemainingAmount money.Decimal  `json:"remaining_amount"`
+		MonthlyPayment  money.Decimal  `json:"monthly_payment"`
 		AnnualInterest  float64        `json:"annual_interest"`
 		Type            string         `json:"type" gorm:"type:varchar(10);default:FIXED"` // FIXED, FIXED_AMORTIZED, FLOAT, FLOAT_AMORTIZED
 		PaymentDeadline postgreSQLDate `json:"payment_deadline"`
@@ -146,9 +153,9 @@ type (
 		UpdatedAt postgreSQLTimestamp `json:"-"`
 		SessionID int64               `json:"session_id"`
 
-		Amount float64 `json:"amount"`
-		Name   string  `json:"name" gorm:"type:varchar(100)"`
-		Type   string  `json:"type" gorm:"type:varchar(15);default:ESSENTIAL"` // ESSENTIAL, NON_ESSENTIAL
+		Amount money.Decimal `json:"amount"`
+		Name   string        `json:"name" gorm:"type:varchar(100)"`
+		Type   string        `json:"type" gorm:"type:varchar(15);default:ESSENTIAL"` // ESSENTIAL, NON_ESSENTIAL
 	}
 )
 
@@ -352,3 +359,13 @@ func migrateExpense(dbSvc *db.Service) error {
 
 	return nil
 }
+
+func migrateExchangeRate(dbSvc *db.Service) error {
+	// * versions of the offline rate table, see currency.Version
+	rates, err := currency.LoadFile("exchange_rates.json")
+	if err != nil {
+		return err
+	}
+
+	return dbSvc.ExchangeRate.Upsert(dbSvc.GDB, rates)
+}
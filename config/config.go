@@ -25,6 +25,8 @@ type Configuration struct {
 	JwtSecret    string `env:"JWT_SECRET"`
 	JwtDuration  int    `env:"JWT_DURATION"`
 	JwtAlgorithm string `env:"JWT_ALGORITHM"`
+
+	RoundingMode string `env:"ROUNDING_MODE"` // HALF_UP by default, or HALF_EVEN
 }
 
 // Load returns Configuration struct
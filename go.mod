@@ -4,7 +4,6 @@ go 1.22.2
 
 require (
 	github.com/M15t/ghoul v1.0.20
-	github.com/allegro/bigcache/v3 v3.1.0
 	github.com/aws/aws-lambda-go v1.41.0
 	github.com/go-gormigrate/gormigrate/v2 v2.1.1
 	github.com/golang-module/carbon/v2 v2.2.3
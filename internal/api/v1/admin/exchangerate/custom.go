@@ -0,0 +1,12 @@
+package exchangerate
+
+import (
+	"net/http"
+
+	"github.com/M15t/ghoul/pkg/server"
+)
+
+// Custom error
+var (
+	ErrUSDRateFixed = server.NewHTTPError(http.StatusBadRequest, "USD_RATE_FIXED", "Rates are quoted against USD, its rate is always 1")
+)
@@ -0,0 +1,106 @@
+package exchangerate
+
+import (
+	"dullahan/internal/model"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HTTP represents exchange rate http service
+type HTTP struct {
+	svc  Service
+	auth model.Auth
+}
+
+// Service represents exchange rate application interface
+type Service interface {
+	List(c echo.Context, authUsr *model.AuthAdmin) ([]*model.ExchangeRate, error)
+	SaveVersion(c echo.Context, authUsr *model.AuthAdmin, data VersionData) ([]*model.ExchangeRate, error)
+}
+
+// NewHTTP creates new exchange rate http service
+func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
+	h := HTTP{svc, auth}
+
+	// swagger:operation GET /v1/admin/exchange-rates admin-exchange-rates adminExchangeRateList
+	// ---
+	// summary: Returns every version of the exchange rates
+	// responses:
+	//   "200":
+	//     description: The exchange rates ordered by effective date
+	//     schema:
+	//       "$ref": "#/definitions/AdminExchangeRateListResp"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("", h.list)
+
+	// swagger:operation PUT /v1/admin/exchange-rates admin-exchange-rates adminExchangeRateSaveVersion
+	// ---
+	// summary: Sets the rates effective from a date, replacing the ones already set on that date
+	// parameters:
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/AdminExchangeRateVersionData"
+	// responses:
+	//   "200":
+	//     description: The saved exchange rates
+	//     schema:
+	//       "$ref": "#/definitions/AdminExchangeRateListResp"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.PUT("", h.saveVersion)
+}
+
+// VersionData contains the rates of a version from json request
+// swagger:model AdminExchangeRateVersionData
+type VersionData struct {
+	// example: 2026-10-01T00:00:00Z
+	EffectiveDate time.Time `json:"effective_date" validate:"required"`
+	// Units of each currency one US dollar buys, keyed by ISO 4217 code
+	// example: {"EUR": 0.92, "VND": 25400}
+	Rates map[string]float64 `json:"rates" validate:"required,min=1,dive,keys,iso4217,endkeys,gt=0"`
+}
+
+// ListResp contains list of exchange rates
+// swagger:model AdminExchangeRateListResp
+type ListResp struct {
+	Data []*model.ExchangeRate `json:"data"`
+}
+
+func (h *HTTP) list(c echo.Context) error {
+	resp, err := h.svc.List(c, h.auth.Admin(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ListResp{Data: resp})
+}
+
+func (h *HTTP) saveVersion(c echo.Context) error {
+	r := VersionData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.SaveVersion(c, h.auth.Admin(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ListResp{Data: resp})
+}
@@ -0,0 +1,58 @@
+package exchangerate
+
+import (
+	"dullahan/internal/model"
+
+	"github.com/M15t/ghoul/pkg/rbac"
+	"github.com/M15t/ghoul/pkg/server"
+	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
+)
+
+// List returns every version of the exchange rates
+func (s *ExchangeRate) List(c echo.Context, authUsr *model.AuthAdmin) ([]*model.ExchangeRate, error) {
+	if err := s.enforce(authUsr, model.ActionViewAll); err != nil {
+		return nil, err
+	}
+
+	rates := []*model.ExchangeRate{}
+	if err := s.db.ExchangeRate.ListAll(s.db.GDB, &rates); err != nil {
+		return nil, server.NewHTTPInternalError("Error listing exchange rates").SetInternal(err)
+	}
+
+	return rates, nil
+}
+
+// SaveVersion sets the rates effective from a date, replacing the ones already set on that date
+func (s *ExchangeRate) SaveVersion(c echo.Context, authUsr *model.AuthAdmin, data VersionData) ([]*model.ExchangeRate, error) {
+	if err := s.enforce(authUsr, model.ActionCreateAll); err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Rates[model.CurrencyUSD]; ok {
+		return nil, ErrUSDRateFixed
+	}
+
+	rates := make([]*model.ExchangeRate, 0, len(data.Rates))
+	for currency, rate := range data.Rates {
+		rates = append(rates, &model.ExchangeRate{
+			Currency:      currency,
+			Rate:          rate,
+			EffectiveDate: datatypes.Date(data.EffectiveDate),
+		})
+	}
+
+	if err := s.db.ExchangeRate.Upsert(s.db.GDB, rates); err != nil {
+		return nil, server.NewHTTPInternalError("Error saving exchange rates").SetInternal(err)
+	}
+
+	return rates, nil
+}
+
+// enforce checks ExchangeRate permission to perform the action
+func (s *ExchangeRate) enforce(authUsr *model.AuthAdmin, action string) error {
+	if !s.rbac.Enforce(authUsr.Role, model.ObjectExchangeRate, action) {
+		return rbac.ErrForbiddenAction
+	}
+	return nil
+}
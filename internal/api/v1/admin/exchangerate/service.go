@@ -0,0 +1,18 @@
+package exchangerate
+
+import (
+	"dullahan/internal/db"
+
+	"github.com/M15t/ghoul/pkg/rbac"
+)
+
+// New creates new exchange rate application service
+func New(db *db.Service, rbacSvc rbac.Intf) *ExchangeRate {
+	return &ExchangeRate{db: db, rbac: rbacSvc}
+}
+
+// ExchangeRate represents exchange rate application service
+type ExchangeRate struct {
+	db   *db.Service
+	rbac rbac.Intf
+}
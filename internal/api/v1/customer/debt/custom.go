@@ -8,5 +8,8 @@ import (
 
 // Custom error
 var (
-	ErrDebtNotFound = server.NewHTTPError(http.StatusBadRequest, "DEBT_NOTFOUND", "Debt not found")
+	ErrDebtNotFound        = server.NewHTTPError(http.StatusBadRequest, "DEBT_NOTFOUND", "Debt not found")
+	ErrDebtRateNotFound    = server.NewHTTPError(http.StatusBadRequest, "DEBT_RATE_NOTFOUND", "Debt rate not found")
+	ErrDebtNotFloating     = server.NewHTTPError(http.StatusBadRequest, "DEBT_NOT_FLOATING", "Rate schedule is only available for FLOAT and FLOAT_AMORTIZED debts")
+	ErrInvalidDebtRateBand = server.NewHTTPError(http.StatusBadRequest, "INVALID_DEBT_RATE_BAND", "Rate floor must not be greater than rate cap")
 )
@@ -3,8 +3,10 @@ package debt
 import (
 	"dullahan/internal/model"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/M15t/ghoul/pkg/server"
 	httputil "github.com/M15t/ghoul/pkg/util/http"
 
 	"github.com/labstack/echo/v4"
@@ -21,6 +23,10 @@ type Service interface {
 	Create(c echo.Context, authUsr *model.AuthCustomer, data CreationData) (*model.Debt, error)
 	Update(c echo.Context, authUsr *model.AuthCustomer, id int64, data UpdateData) (*model.Debt, error)
 	Delete(c echo.Context, authUsr *model.AuthCustomer, id int64) error
+	ListRates(c echo.Context, authUsr *model.AuthCustomer, id int64) ([]*model.DebtRate, error)
+	CreateRate(c echo.Context, authUsr *model.AuthCustomer, id int64, data RateCreationData) (*model.DebtRate, error)
+	UpdateRate(c echo.Context, authUsr *model.AuthCustomer, id, rateID int64, data RateUpdateData) (*model.DebtRate, error)
+	DeleteRate(c echo.Context, authUsr *model.AuthCustomer, id, rateID int64) error
 }
 
 // NewHTTP creates new card http service
@@ -107,6 +113,126 @@ func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
 	//   "500":
 	//     "$ref": "#/responses/errDetails"
 	eg.DELETE("/:id", h.delete)
+
+	// swagger:operation GET /v1/customer/debts/{id}/rates customer-debts customerDebtRateList
+	// ---
+	// summary: Returns the rate schedule of a floating debt
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of debt
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: The rate schedule
+	//     schema:
+	//       "$ref": "#/definitions/CustomerDebtRateListResp"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/:id/rates", h.listRates)
+
+	// swagger:operation POST /v1/customer/debts/{id}/rates customer-debts customerDebtRateCreate
+	// ---
+	// summary: Schedules a rate change of a floating debt
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of debt
+	//   type: integer
+	//   required: true
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerDebtRateCreationData"
+	// responses:
+	//   "200":
+	//     description: The new debt rate
+	//     schema:
+	//       "$ref": "#/definitions/DebtRate"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.POST("/:id/rates", h.createRate)
+
+	// swagger:operation PATCH /v1/customer/debts/{id}/rates/{rate_id} customer-debts customerDebtRateUpdate
+	// ---
+	// summary: Update a scheduled rate change of a floating debt
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of debt
+	//   type: integer
+	//   required: true
+	// - name: rate_id
+	//   in: path
+	//   description: id of debt rate
+	//   type: integer
+	//   required: true
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerDebtRateUpdateData"
+	// responses:
+	//   "200":
+	//     description: The updated debt rate
+	//     schema:
+	//       "$ref": "#/definitions/DebtRate"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "404":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.PATCH("/:id/rates/:rate_id", h.updateRate)
+
+	// swagger:operation DELETE /v1/customer/debts/{id}/rates/{rate_id} customer-debts customerDebtRateDelete
+	// ---
+	// summary: Deletes a scheduled rate change of a floating debt
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of debt
+	//   type: integer
+	//   required: true
+	// - name: rate_id
+	//   in: path
+	//   description: id of debt rate
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/ok"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "404":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.DELETE("/:id/rates/:rate_id", h.deleteRate)
 }
 
 // CreationData contains debt data from json request
@@ -115,15 +241,20 @@ type CreationData struct {
 	// example: Bank of America
 	Name string `json:"name" validate:"required,max=50"`
 	//	example: 30000
-	RemainingAmount float64 `json:"remaining_amount" validate:"required,gte=0"`
+	RemainingAmount float64 `json:"remaining_amount" validate:"required,gte=0,lte=99999999999999"`
 	// example: 500
-	MonthlyPayment float64 `json:"monthly_payment" validate:"required,gte=0"`
+	MonthlyPayment float64 `json:"monthly_payment" validate:"required,gte=0,lte=99999999999999"`
 	// example: 11
 	AnnualInterest float64 `json:"annual_interest" validate:"gte=0"`
 	// example: FIXED
 	Type string `json:"type" validate:"required,oneof=FIXED FIXED_AMORTIZED FLOAT FLOAT_AMORTIZED"` // FIXED, FIXED_AMORTIZED, FLOAT, FLOAT_AMORTIZED
 	// example: 2025-12-31T00:00:00Z
 	PaymentDeadline time.Time `json:"payment_deadline"`
+	// example: 1
+	Priority int `json:"priority" validate:"gte=0"`
+	// ISO 4217 code of the amounts, the session currency when omitted
+	// example: EUR
+	Currency string `json:"currency,omitempty" validate:"omitempty,iso4217"`
 }
 
 // UpdateData contains debt data from json request
@@ -132,15 +263,63 @@ type UpdateData struct {
 	// example: Bank of America
 	Named *string `json:"name,omitempty" validate:"omitempty,max=50"`
 	//	example: 30000
-	RemainingAmount *float64 `json:"remaining_amount,omitempty" validate:"omitempty,gte=0"`
+	RemainingAmount *float64 `json:"remaining_amount,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
 	// example: 500
-	MonthlyPayment *float64 `json:"monthly_payment,omitempty" validate:"omitempty,gte=0"`
+	MonthlyPayment *float64 `json:"monthly_payment,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
 	// example: 11
 	AnnualInterest *float64 `json:"annual_interest,omitempty" validate:"gte=0"`
 	// example: FIXED
 	Type *string `json:"type,omitempty" validate:"omitempty,oneof=FIXED FIXED_AMORTIZED FLOAT FLOAT_AMORTIZED"` // FIXED, FIXED_AMORTIZED, FLOAT, FLOAT_AMORTIZED
 	// example: 2025-12-31T00:00:00Z
 	PaymentDeadline *time.Time `json:"payment_deadline,omitempty"`
+	// example: 1
+	Priority *int `json:"priority,omitempty" validate:"omitempty,gte=0"`
+	// example: EUR
+	Currency *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+}
+
+// RateCreationData contains debt rate data from json request
+// swagger:model CustomerDebtRateCreationData
+type RateCreationData struct {
+	// example: INDEX
+	Type string `json:"type" validate:"required,oneof=RATE INDEX"` // RATE, INDEX
+	// example: 2025-06-01T00:00:00Z
+	EffectiveDate time.Time `json:"effective_date" validate:"required"`
+	// example: 7.5
+	AnnualInterest float64 `json:"annual_interest" validate:"gte=0"`
+	// example: 4.25
+	IndexRate float64 `json:"index_rate"`
+	// example: 2.5
+	Margin float64 `json:"margin"`
+	// example: 9
+	Cap *float64 `json:"cap,omitempty" validate:"omitempty,gte=0"`
+	// example: 3
+	Floor *float64 `json:"floor,omitempty" validate:"omitempty,gte=0"`
+}
+
+// RateUpdateData contains debt rate data from json request
+// swagger:model CustomerDebtRateUpdateData
+type RateUpdateData struct {
+	// example: INDEX
+	Type *string `json:"type,omitempty" validate:"omitempty,oneof=RATE INDEX"` // RATE, INDEX
+	// example: 2025-06-01T00:00:00Z
+	EffectiveDate *time.Time `json:"effective_date,omitempty"`
+	// example: 7.5
+	AnnualInterest *float64 `json:"annual_interest,omitempty" validate:"omitempty,gte=0"`
+	// example: 4.25
+	IndexRate *float64 `json:"index_rate,omitempty"`
+	// example: 2.5
+	Margin *float64 `json:"margin,omitempty"`
+	// example: 9
+	Cap *float64 `json:"cap,omitempty" validate:"omitempty,gte=0"`
+	// example: 3
+	Floor *float64 `json:"floor,omitempty" validate:"omitempty,gte=0"`
+}
+
+// RateListResp contains list of debt rates
+// swagger:model CustomerDebtRateListResp
+type RateListResp struct {
+	Data []*model.DebtRate `json:"data"`
 }
 
 func (h *HTTP) create(c echo.Context) error {
@@ -186,3 +365,82 @@ func (h *HTTP) delete(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+func (h *HTTP) listRates(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.svc.ListRates(c, h.auth.Customer(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, RateListResp{Data: resp})
+}
+
+func (h *HTTP) createRate(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+	r := RateCreationData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateRate(c, h.auth.Customer(c), id, r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) updateRate(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+	rateID, err := reqRateID(c)
+	if err != nil {
+		return err
+	}
+	u := RateUpdateData{}
+	if err := c.Bind(&u); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.UpdateRate(c, h.auth.Customer(c), id, rateID, u)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) deleteRate(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+	rateID, err := reqRateID(c)
+	if err != nil {
+		return err
+	}
+	if err := h.svc.DeleteRate(c, h.auth.Customer(c), id, rateID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// reqRateID returns rate_id url parameter
+func reqRateID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("rate_id"), 10, 64)
+	if err != nil {
+		return 0, server.NewHTTPValidationError("Invalid rate ID")
+	}
+	return id, nil
+}
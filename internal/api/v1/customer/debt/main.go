@@ -1,12 +1,15 @@
 package debt
 
 import (
+	"dullahan/internal/db/exchangerate"
 	"dullahan/internal/model"
+	"dullahan/internal/money"
 
 	"github.com/M15t/ghoul/pkg/rbac"
 	"github.com/M15t/ghoul/pkg/server"
 	"github.com/labstack/echo/v4"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 
 	structutil "github.com/M15t/ghoul/pkg/util/struct"
 )
@@ -17,14 +20,26 @@ func (s *Debt) Create(c echo.Context, authUsr *model.AuthCustomer, data Creation
 		return nil, err
 	}
 
+	if data.Currency != "" {
+		ok, err := s.db.ExchangeRate.Convertible(s.db.GDB, data.Currency, authUsr.SessionID)
+		if err != nil {
+			return nil, server.NewHTTPInternalError("Error checking exchange rates").SetInternal(err)
+		}
+		if !ok {
+			return nil, exchangerate.ErrNotFound
+		}
+	}
+
 	rec := &model.Debt{
 		Name:            data.Name,
-		RemainingAmount: data.RemainingAmount,
-		MonthlyPayment:  data.MonthlyPayment,
+		RemainingAmount: money.FromFloat(data.RemainingAmount),
+		MonthlyPayment:  money.FromFloat(data.MonthlyPayment),
 		AnnualInterest:  data.AnnualInterest,
 		Type:            data.Type,
 		PaymentDeadline: datatypes.Date(data.PaymentDeadline),
+		Priority:        data.Priority,
 		SessionID:       authUsr.SessionID,
+		Currency:        data.Currency,
 	}
 
 	if err := s.db.Debt.Create(s.db.GDB, rec); err != nil {
@@ -45,6 +60,16 @@ func (s *Debt) Update(c echo.Context, authUsr *model.AuthCustomer, id int64, dat
 		return nil, ErrDebtNotFound
 	}
 
+	if data.Currency != nil && *data.Currency != "" {
+		ok, err := s.db.ExchangeRate.Convertible(s.db.GDB, *data.Currency, authUsr.SessionID)
+		if err != nil {
+			return nil, server.NewHTTPInternalError("Error checking exchange rates").SetInternal(err)
+		}
+		if !ok {
+			return nil, exchangerate.ErrNotFound
+		}
+	}
+
 	// optimistic update
 	updates := structutil.ToMap(data)
 	if err := s.db.Debt.Update(s.db.GDB, updates, id); err != nil {
@@ -71,13 +96,144 @@ func (s *Debt) Delete(c echo.Context, authUsr *model.AuthCustomer, id int64) err
 		return ErrDebtNotFound.SetInternal(err)
 	}
 
-	if err := s.db.Debt.Delete(s.db.GDB, id); err != nil {
+	if err := s.db.GDB.Transaction(func(tx *gorm.DB) error {
+		if err := s.db.DebtRate.Delete(tx, `debt_id = ?`, id); err != nil {
+			return err
+		}
+		return s.db.Debt.Delete(tx, id)
+	}); err != nil {
 		return server.NewHTTPInternalError("Error deleting purchase").SetInternal(err)
 	}
 
 	return nil
 }
 
+// ListRates returns the rate schedule of a floating debt
+func (s *Debt) ListRates(c echo.Context, authUsr *model.AuthCustomer, id int64) ([]*model.DebtRate, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.viewFloatingDebt(authUsr, id); err != nil {
+		return nil, err
+	}
+
+	rates := []*model.DebtRate{}
+	if err := s.db.DebtRate.ListByDebt(s.db.GDB, &rates, id); err != nil {
+		return nil, server.NewHTTPInternalError("Error listing debt rates").SetInternal(err)
+	}
+
+	return rates, nil
+}
+
+// CreateRate schedules a rate change for a floating debt
+func (s *Debt) CreateRate(c echo.Context, authUsr *model.AuthCustomer, id int64, data RateCreationData) (*model.DebtRate, error) {
+	if err := s.enforce(authUsr, model.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.viewFloatingDebt(authUsr, id); err != nil {
+		return nil, err
+	}
+
+	if !isValidRateBand(data.Cap, data.Floor) {
+		return nil, ErrInvalidDebtRateBand
+	}
+
+	rec := &model.DebtRate{
+		DebtID:         id,
+		Type:           data.Type,
+		EffectiveDate:  datatypes.Date(data.EffectiveDate),
+		AnnualInterest: data.AnnualInterest,
+		IndexRate:      data.IndexRate,
+		Margin:         data.Margin,
+		Cap:            data.Cap,
+		Floor:          data.Floor,
+	}
+
+	if err := s.db.DebtRate.Create(s.db.GDB, rec); err != nil {
+		return nil, server.NewHTTPInternalError("Error creating debt rate").SetInternal(err)
+	}
+
+	return rec, nil
+}
+
+// UpdateRate updates a scheduled rate change of a floating debt
+func (s *Debt) UpdateRate(c echo.Context, authUsr *model.AuthCustomer, id, rateID int64, data RateUpdateData) (*model.DebtRate, error) {
+	if err := s.enforce(authUsr, model.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.viewFloatingDebt(authUsr, id); err != nil {
+		return nil, err
+	}
+
+	rec := new(model.DebtRate)
+	if err := s.db.DebtRate.View(s.db.GDB, rec, `id = ? AND debt_id = ?`, rateID, id); err != nil {
+		return nil, ErrDebtRateNotFound.SetInternal(err)
+	}
+
+	rateCap, rateFloor := rec.Cap, rec.Floor
+	if data.Cap != nil {
+		rateCap = data.Cap
+	}
+	if data.Floor != nil {
+		rateFloor = data.Floor
+	}
+	if !isValidRateBand(rateCap, rateFloor) {
+		return nil, ErrInvalidDebtRateBand
+	}
+
+	// optimistic update
+	updates := structutil.ToMap(data)
+	if err := s.db.DebtRate.Update(s.db.GDB, updates, rateID); err != nil {
+		return nil, server.NewHTTPInternalError("Error updating debt rate").SetInternal(err)
+	}
+
+	// * get latest record
+	if err := s.db.DebtRate.View(s.db.GDB, rec, rateID); err != nil {
+		return nil, ErrDebtRateNotFound.SetInternal(err)
+	}
+
+	return rec, nil
+}
+
+// DeleteRate deletes a scheduled rate change of a floating debt
+func (s *Debt) DeleteRate(c echo.Context, authUsr *model.AuthCustomer, id, rateID int64) error {
+	if err := s.enforce(authUsr, model.ActionUpdate); err != nil {
+		return err
+	}
+
+	if _, err := s.viewFloatingDebt(authUsr, id); err != nil {
+		return err
+	}
+
+	// * check legit debt
+	if existed, err := s.db.DebtRate.Exist(s.db.GDB, `id = ? AND debt_id = ?`, rateID, id); err != nil || !existed {
+		return ErrDebtRateNotFound.SetInternal(err)
+	}
+
+	if err := s.db.DebtRate.Delete(s.db.GDB, rateID); err != nil {
+		return server.NewHTTPInternalError("Error deleting debt rate").SetInternal(err)
+	}
+
+	return nil
+}
+
+// viewFloatingDebt returns the debt of current session, only when its rate may float
+func (s *Debt) viewFloatingDebt(authUsr *model.AuthCustomer, id int64) (*model.Debt, error) {
+	rec := new(model.Debt)
+	if err := s.db.Debt.View(s.db.GDB, rec, `id = ? AND session_id = ?`, id, authUsr.SessionID); err != nil {
+		return nil, ErrDebtNotFound.SetInternal(err)
+	}
+
+	if !rec.IsFloating() {
+		return nil, ErrDebtNotFloating
+	}
+
+	return rec, nil
+}
+
 // enforce checks Debt permission to perform the action
 func (s *Debt) enforce(authUsr *model.AuthCustomer, action string) error {
 	if !s.rbac.Enforce(authUsr.Role, model.ObjectDebt, action) {
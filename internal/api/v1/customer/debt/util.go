@@ -1,5 +1,9 @@
 package debt
 
+func isValidRateBand(rateCap, rateFloor *float64) bool {
+	return rateCap == nil || rateFloor == nil || *rateFloor <= *rateCap
+}
+
 // func (s *Debt) recalculateDebt(debt *model.Debt) error {
 // 	interestPaidOffEachMonth := s.calculateInterestPaid(debt.AnnualInterest, debt.MonthlyPayment)
 
@@ -8,5 +8,6 @@ import (
 
 // Custom error
 var (
-	ErrExpenseNotFound = server.NewHTTPError(http.StatusBadRequest, "EXPENSE_NOTFOUND", "Expense not found")
+	ErrExpenseNotFound      = server.NewHTTPError(http.StatusBadRequest, "EXPENSE_NOTFOUND", "Expense not found")
+	ErrInvalidExpensePeriod = server.NewHTTPError(http.StatusBadRequest, "INVALID_EXPENSE_PERIOD", "End date must not be before start date")
 )
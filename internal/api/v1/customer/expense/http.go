@@ -3,6 +3,7 @@ package expense
 import (
 	"dullahan/internal/model"
 	"net/http"
+	"time"
 
 	httputil "github.com/M15t/ghoul/pkg/util/http"
 
@@ -116,7 +117,22 @@ type CreationData struct {
 	// example: ESSENTIAL
 	Type string `json:"type" validate:"required,oneof=ESSENTIAL NON_ESSENTIAL"`
 	// example: 300
-	Amount float64 `json:"amount" validate:"gte=0"`
+	Amount float64 `json:"amount" validate:"gte=0,lte=99999999999999"`
+	// How often the amount occurs, MONTHLY when omitted
+	// example: ANNUAL
+	Frequency string `json:"frequency,omitempty" validate:"omitempty,oneof=WEEKLY BIWEEKLY SEMI_MONTHLY MONTHLY QUARTERLY ANNUAL ONE_TIME"`
+	// A date the amount occurs on, required once only, the creation date when omitted
+	// example: 2026-03-15T00:00:00Z
+	AnchorDate *time.Time `json:"anchor_date,omitempty" validate:"required_if=Frequency ONE_TIME"`
+	// The first month it counts, already started when omitted
+	// example: 2026-09-01T00:00:00Z
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// The last month it counts, never ends when omitted
+	// example: 2029-08-31T00:00:00Z
+	EndDate *time.Time `json:"end_date,omitempty"`
+	// ISO 4217 code of the amounts, the session currency when omitted
+	// example: EUR
+	Currency string `json:"currency,omitempty" validate:"omitempty,iso4217"`
 }
 
 // UpdateData contains expense data from json request
@@ -127,7 +143,17 @@ type UpdateData struct {
 	// example: ESSENTIAL
 	Type *string `json:"type,omitempty" validate:"omitempty,oneof=ESSENTIAL NON_ESSENTIAL"`
 	// example: 300
-	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gte=0"`
+	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
+	// example: ANNUAL
+	Frequency *string `json:"frequency,omitempty" validate:"omitempty,oneof=WEEKLY BIWEEKLY SEMI_MONTHLY MONTHLY QUARTERLY ANNUAL ONE_TIME"`
+	// example: 2026-03-15T00:00:00Z
+	AnchorDate *time.Time `json:"anchor_date,omitempty"`
+	// example: 2026-09-01T00:00:00Z
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// example: 2029-08-31T00:00:00Z
+	EndDate *time.Time `json:"end_date,omitempty"`
+	// example: EUR
+	Currency *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
 }
 
 func (h *HTTP) create(c echo.Context) error {
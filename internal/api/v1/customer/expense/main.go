@@ -1,11 +1,14 @@
 package expense
 
 import (
+	"dullahan/internal/db/exchangerate"
 	"dullahan/internal/model"
+	"dullahan/internal/money"
 
 	"github.com/M15t/ghoul/pkg/rbac"
 	"github.com/M15t/ghoul/pkg/server"
 	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
 
 	structutil "github.com/M15t/ghoul/pkg/util/struct"
 )
@@ -16,11 +19,29 @@ func (s *Expense) Create(c echo.Context, authUsr *model.AuthCustomer, data Creat
 		return nil, err
 	}
 
+	if data.Currency != "" {
+		ok, err := s.db.ExchangeRate.Convertible(s.db.GDB, data.Currency, authUsr.SessionID)
+		if err != nil {
+			return nil, server.NewHTTPInternalError("Error checking exchange rates").SetInternal(err)
+		}
+		if !ok {
+			return nil, exchangerate.ErrNotFound
+		}
+	}
+
 	rec := &model.Expense{
 		Name:      data.Name,
 		Type:      data.Type,
-		Amount:    data.Amount,
+		Amount:    money.FromFloat(data.Amount),
 		SessionID: authUsr.SessionID,
+		Currency:  data.Currency,
+
+		Schedule: model.NewSchedule(data.Frequency, data.AnchorDate),
+	}
+
+	rec.SetPeriod(data.StartDate, data.EndDate)
+	if !rec.IsValidPeriod() {
+		return nil, ErrInvalidExpensePeriod
 	}
 
 	if err := s.db.Expense.Create(s.db.GDB, rec); err != nil {
@@ -28,7 +49,7 @@ func (s *Expense) Create(c echo.Context, authUsr *model.AuthCustomer, data Creat
 	}
 
 	// * recalcuate total expense
-	if err := s.updateCurrentSession(authUsr.SessionID, data.Type); err != nil {
+	if err := s.updateCurrentSession(authUsr.SessionID); err != nil {
 		return nil, server.NewHTTPInternalError("Error updating current session").SetInternal(err)
 	}
 
@@ -46,8 +67,40 @@ func (s *Expense) Update(c echo.Context, authUsr *model.AuthCustomer, id int64,
 		return nil, ErrExpenseNotFound
 	}
 
+	if data.Currency != nil && *data.Currency != "" {
+		ok, err := s.db.ExchangeRate.Convertible(s.db.GDB, *data.Currency, authUsr.SessionID)
+		if err != nil {
+			return nil, server.NewHTTPInternalError("Error checking exchange rates").SetInternal(err)
+		}
+		if !ok {
+			return nil, exchangerate.ErrNotFound
+		}
+	}
+
+	// * an item must not end before it starts
+	if data.StartDate != nil || data.EndDate != nil {
+		current := new(model.Expense)
+		if err := s.db.Expense.View(s.db.GDB, current, id); err != nil {
+			return nil, ErrExpenseNotFound.SetInternal(err)
+		}
+
+		current.SetPeriod(data.StartDate, data.EndDate)
+		if !current.IsValidPeriod() {
+			return nil, ErrInvalidExpensePeriod
+		}
+	}
+
 	// optimistic update
 	updates := structutil.ToMap(data)
+	if data.AnchorDate != nil {
+		updates["anchor_date"] = datatypes.Date(*data.AnchorDate)
+	}
+	if data.StartDate != nil {
+		updates["start_date"] = datatypes.Date(*data.StartDate)
+	}
+	if data.EndDate != nil {
+		updates["end_date"] = datatypes.Date(*data.EndDate)
+	}
 	if err := s.db.Expense.Update(s.db.GDB, updates, id); err != nil {
 		return nil, server.NewHTTPInternalError("Error updating purchase").SetInternal(err)
 	}
@@ -59,7 +112,7 @@ func (s *Expense) Update(c echo.Context, authUsr *model.AuthCustomer, id int64,
 	}
 
 	// * recalculate total expense
-	if err := s.updateCurrentSession(authUsr.SessionID, rec.Type); err != nil {
+	if err := s.updateCurrentSession(authUsr.SessionID); err != nil {
 		return nil, server.NewHTTPInternalError("Error updating current session").SetInternal(err)
 	}
 
@@ -83,7 +136,7 @@ func (s *Expense) Delete(c echo.Context, authUsr *model.AuthCustomer, id int64)
 	}
 
 	// * recalculate total expense
-	if err := s.updateCurrentSession(authUsr.SessionID, rec.Type); err != nil {
+	if err := s.updateCurrentSession(authUsr.SessionID); err != nil {
 		return server.NewHTTPInternalError("Error updating current session").SetInternal(err)
 	}
 
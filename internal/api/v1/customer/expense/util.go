@@ -4,24 +4,30 @@ import (
 	"dullahan/internal/model"
 )
 
-func (s *Expense) calculateExpense(sessionID int64, dataType string) float64 {
-	// * tricky part
-	var newExpense float64 = 0.0
-	s.db.Expense.SumExpenseByType(s.db.GDB, &newExpense, dataType, sessionID)
+func (s *Expense) calculateExpense(sessionID int64, dataType string) (float64, error) {
+	// * tricky part, summed up per currency then converted to the session currency
+	totalExpenses := []*model.CurrencyAmount{}
+	if err := s.db.Expense.SumExpenseByType(s.db.GDB, &totalExpenses, dataType, sessionID); err != nil {
+		return 0, err
+	}
 
-	return newExpense
+	return s.db.ExchangeRate.SumToSession(s.db.GDB, totalExpenses, sessionID)
 }
 
-func (s *Expense) updateCurrentSession(sessionID int64, dataType string) error {
-	// * just recalculate the total of each type in sessions tbl
-	newExpense := s.cr.RoundFloat(s.calculateExpense(sessionID, dataType))
-	updates := map[string]interface{}{}
+func (s *Expense) updateCurrentSession(sessionID int64) error {
+	// * recalculate both types, an update may move the expense from one to the other
+	essential, err := s.calculateExpense(sessionID, model.ExpenseTypeEssential)
+	if err != nil {
+		return err
+	}
+	nonEssential, err := s.calculateExpense(sessionID, model.ExpenseTypeNonEssential)
+	if err != nil {
+		return err
+	}
 
-	switch dataType {
-	case model.ExpenseTypeEssential:
-		updates["total_essential_expense"] = newExpense
-	default:
-		updates["total_non_essential_expense"] = newExpense
+	updates := map[string]interface{}{
+		"total_essential_expense":     s.cr.RoundFloat(essential),
+		"total_non_essential_expense": s.cr.RoundFloat(nonEssential),
 	}
 
 	// * update session
@@ -0,0 +1,12 @@
+package goal
+
+import (
+	"net/http"
+
+	"github.com/M15t/ghoul/pkg/server"
+)
+
+// Custom error
+var (
+	ErrGoalNotFound = server.NewHTTPError(http.StatusBadRequest, "GOAL_NOTFOUND", "Goal not found")
+)
@@ -0,0 +1,214 @@
+package goal
+
+import (
+	"dullahan/internal/model"
+	"net/http"
+	"time"
+
+	httputil "github.com/M15t/ghoul/pkg/util/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HTTP represents goal http service
+type HTTP struct {
+	svc  Service
+	auth model.Auth
+}
+
+// Service represents goal application interface
+type Service interface {
+	List(c echo.Context, authUsr *model.AuthCustomer) ([]*model.Goal, error)
+	Create(c echo.Context, authUsr *model.AuthCustomer, data CreationData) (*model.Goal, error)
+	Update(c echo.Context, authUsr *model.AuthCustomer, id int64, data UpdateData) (*model.Goal, error)
+	Delete(c echo.Context, authUsr *model.AuthCustomer, id int64) error
+}
+
+// NewHTTP creates new goal http service
+func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
+	h := HTTP{svc, auth}
+
+	// swagger:operation GET /v1/customer/goals customer-goals customerGoalList
+	// ---
+	// summary: Returns the goals in funding order with their latest forecast
+	// responses:
+	//   "200":
+	//     description: List of goals
+	//     schema:
+	//       "$ref": "#/definitions/CustomerGoalListResp"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("", h.list)
+
+	// swagger:operation POST /v1/customer/goals customer-goals customerGoalCreate
+	// ---
+	// summary: Creates new goal
+	// parameters:
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerGoalCreationData"
+	// responses:
+	//   "200":
+	//     description: The new goal
+	//     schema:
+	//       "$ref": "#/definitions/Goal"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.POST("", h.create)
+
+	// swagger:operation PATCH /v1/customer/goals/{id} customer-goals customerGoalUpdate
+	// ---
+	// summary: Update goal information
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of goal
+	//   type: integer
+	//   required: true
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerGoalUpdateData"
+	// responses:
+	//   "200":
+	//     description: The updated goal
+	//     schema:
+	//       "$ref": "#/definitions/Goal"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "404":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.PATCH("/:id", h.update)
+
+	// swagger:operation DELETE /v1/customer/goals/{id} customer-goals customerGoalDelete
+	// ---
+	// summary: Deletes a goal
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of goal
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/ok"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "404":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.DELETE("/:id", h.delete)
+}
+
+// CreationData contains goal data from json request
+// swagger:model CustomerGoalCreationData
+type CreationData struct {
+	// example: House down payment
+	Name string `json:"name" validate:"required,max=100"`
+	// example: 40000
+	TargetAmount float64 `json:"target_amount" validate:"required,gt=0,lte=99999999999999"`
+	// example: 2029-06-30T00:00:00Z
+	TargetDate time.Time `json:"target_date" validate:"required"`
+	// example: 1
+	Priority int `json:"priority" validate:"gte=0"`
+}
+
+// UpdateData contains goal data from json request
+// swagger:model CustomerGoalUpdateData
+type UpdateData struct {
+	// example: House down payment
+	Name *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	// example: 40000
+	TargetAmount *float64 `json:"target_amount,omitempty" validate:"omitempty,gt=0,lte=99999999999999"`
+	// example: 2029-06-30T00:00:00Z
+	TargetDate *time.Time `json:"target_date,omitempty"`
+	// example: 1
+	Priority *int `json:"priority,omitempty" validate:"omitempty,gte=0"`
+}
+
+// ListResp contains list of goals
+// swagger:model CustomerGoalListResp
+type ListResp struct {
+	Data []*model.Goal `json:"data"`
+}
+
+func (h *HTTP) list(c echo.Context) error {
+	resp, err := h.svc.List(c, h.auth.Customer(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ListResp{Data: resp})
+}
+
+func (h *HTTP) create(c echo.Context) error {
+	r := CreationData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.Create(c, h.auth.Customer(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) update(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+	u := UpdateData{}
+	if err := c.Bind(&u); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.Update(c, h.auth.Customer(c), id, u)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) delete(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+	if err := h.svc.Delete(c, h.auth.Customer(c), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
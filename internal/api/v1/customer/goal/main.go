@@ -0,0 +1,104 @@
+package goal
+
+import (
+	"dullahan/internal/forecast"
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+
+	"github.com/M15t/ghoul/pkg/rbac"
+	"github.com/M15t/ghoul/pkg/server"
+	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
+
+	structutil "github.com/M15t/ghoul/pkg/util/struct"
+)
+
+// List returns the goals of the current session in funding order
+func (s *Goal) List(c echo.Context, authUsr *model.AuthCustomer) ([]*model.Goal, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	var data []*model.Goal
+	if err := s.db.Goal.ListBySession(s.db.GDB, &data, authUsr.SessionID); err != nil {
+		return nil, server.NewHTTPInternalError("Error listing goals").SetInternal(err)
+	}
+
+	return forecast.OrderGoals(data), nil
+}
+
+// Create creates a new goal
+func (s *Goal) Create(c echo.Context, authUsr *model.AuthCustomer, data CreationData) (*model.Goal, error) {
+	if err := s.enforce(authUsr, model.ActionCreate); err != nil {
+		return nil, err
+	}
+
+	rec := &model.Goal{
+		Name:         data.Name,
+		TargetAmount: money.FromFloat(data.TargetAmount),
+		TargetDate:   datatypes.Date(data.TargetDate),
+		Priority:     data.Priority,
+		SessionID:    authUsr.SessionID,
+	}
+
+	if err := s.db.Goal.Create(s.db.GDB, rec); err != nil {
+		return nil, server.NewHTTPInternalError("Error creating goal").SetInternal(err)
+	}
+
+	return rec, nil
+}
+
+// Update updates goal information
+func (s *Goal) Update(c echo.Context, authUsr *model.AuthCustomer, id int64, data UpdateData) (*model.Goal, error) {
+	if err := s.enforce(authUsr, model.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	// * check legit session
+	if existed, err := s.db.Goal.Exist(s.db.GDB, `id = ? AND session_id = ?`, id, authUsr.SessionID); err != nil || !existed {
+		return nil, ErrGoalNotFound
+	}
+
+	// optimistic update
+	updates := structutil.ToMap(data)
+	if data.TargetDate != nil {
+		updates["target_date"] = datatypes.Date(*data.TargetDate)
+	}
+	if err := s.db.Goal.Update(s.db.GDB, updates, id); err != nil {
+		return nil, server.NewHTTPInternalError("Error updating goal").SetInternal(err)
+	}
+
+	// * get latest record
+	rec := new(model.Goal)
+	if err := s.db.Goal.View(s.db.GDB, rec, id); err != nil {
+		return nil, ErrGoalNotFound.SetInternal(err)
+	}
+
+	return rec, nil
+}
+
+// Delete deletes a goal
+func (s *Goal) Delete(c echo.Context, authUsr *model.AuthCustomer, id int64) error {
+	if err := s.enforce(authUsr, model.ActionDelete); err != nil {
+		return err
+	}
+
+	// * check legit session
+	if existed, err := s.db.Goal.Exist(s.db.GDB, `id = ? AND session_id = ?`, id, authUsr.SessionID); err != nil || !existed {
+		return ErrGoalNotFound.SetInternal(err)
+	}
+
+	if err := s.db.Goal.Delete(s.db.GDB, id); err != nil {
+		return server.NewHTTPInternalError("Error deleting goal").SetInternal(err)
+	}
+
+	return nil
+}
+
+// enforce checks Goal permission to perform the action
+func (s *Goal) enforce(authUsr *model.AuthCustomer, action string) error {
+	if !s.rbac.Enforce(authUsr.Role, model.ObjectGoal, action) {
+		return rbac.ErrForbiddenAction
+	}
+	return nil
+}
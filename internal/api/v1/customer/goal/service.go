@@ -0,0 +1,24 @@
+package goal
+
+import (
+	"dullahan/internal/db"
+
+	"github.com/M15t/ghoul/pkg/rbac"
+)
+
+// New creates new goal application service
+func New(db *db.Service, rbacSvc rbac.Intf, cr Crypter) *Goal {
+	return &Goal{db: db, rbac: rbacSvc, cr: cr}
+}
+
+// Goal represents goal application service
+type Goal struct {
+	db   *db.Service
+	rbac rbac.Intf
+	cr   Crypter
+}
+
+// Crypter represents security interface
+type Crypter interface {
+	RoundFloat(f float64) float64
+}
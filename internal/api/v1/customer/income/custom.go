@@ -8,5 +8,6 @@ import (
 
 // Custom error
 var (
-	ErrIncomeNotFound = server.NewHTTPError(http.StatusBadRequest, "INCOME_NOTFOUND", "Income not found")
+	ErrIncomeNotFound      = server.NewHTTPError(http.StatusBadRequest, "INCOME_NOTFOUND", "Income not found")
+	ErrInvalidIncomePeriod = server.NewHTTPError(http.StatusBadRequest, "INVALID_INCOME_PERIOD", "End date must not be before start date")
 )
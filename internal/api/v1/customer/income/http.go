@@ -3,6 +3,7 @@ package income
 import (
 	"dullahan/internal/model"
 	"net/http"
+	"time"
 
 	httputil "github.com/M15t/ghoul/pkg/util/http"
 
@@ -116,7 +117,31 @@ type CreationData struct {
 	// example: MONTHLY
 	Type string `json:"type" validate:"required,oneof=MONTHLY PASSIVE"`
 	// example: 2000
-	Amount float64 `json:"amount" validate:"gte=0"`
+	Amount float64 `json:"amount" validate:"gte=0,lte=99999999999999"`
+	// Whether the amount is before taxes, taxed by the rules of the session
+	// example: true
+	IsGross bool `json:"is_gross"`
+	// Annual raise in percent, the growth rate of a passive income
+	// example: 3
+	AnnualRaiseRate float64 `json:"annual_raise_rate" validate:"gte=-100,lte=100"`
+	// Month the raise applies, from 1 to 12, the current month when omitted
+	// example: 1
+	RaiseMonth int `json:"raise_month" validate:"omitempty,min=1,max=12"`
+	// How often the amount occurs, MONTHLY when omitted
+	// example: ANNUAL
+	Frequency string `json:"frequency,omitempty" validate:"omitempty,oneof=WEEKLY BIWEEKLY SEMI_MONTHLY MONTHLY QUARTERLY ANNUAL ONE_TIME"`
+	// A date the amount occurs on, required once only, the creation date when omitted
+	// example: 2026-03-15T00:00:00Z
+	AnchorDate *time.Time `json:"anchor_date,omitempty" validate:"required_if=Frequency ONE_TIME"`
+	// The first month it counts, already started when omitted
+	// example: 2026-01-01T00:00:00Z
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// The last month it counts, never ends when omitted
+	// example: 2026-03-31T00:00:00Z
+	EndDate *time.Time `json:"end_date,omitempty"`
+	// ISO 4217 code of the amounts, the session currency when omitted
+	// example: EUR
+	Currency string `json:"currency,omitempty" validate:"omitempty,iso4217"`
 }
 
 // UpdateData contains income data from json request
@@ -127,7 +152,23 @@ type UpdateData struct {
 	// example: MONTHLY
 	Type *string `json:"type,omitempty" validate:"omitempty,oneof=MONTHLY PASSIVE"`
 	// example: 2000
-	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gte=0"`
+	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
+	// example: true
+	IsGross *bool `json:"is_gross,omitempty"`
+	// example: 3
+	AnnualRaiseRate *float64 `json:"annual_raise_rate,omitempty" validate:"omitempty,gte=-100,lte=100"`
+	// example: 1
+	RaiseMonth *int `json:"raise_month,omitempty" validate:"omitempty,min=1,max=12"`
+	// example: ANNUAL
+	Frequency *string `json:"frequency,omitempty" validate:"omitempty,oneof=WEEKLY BIWEEKLY SEMI_MONTHLY MONTHLY QUARTERLY ANNUAL ONE_TIME"`
+	// example: 2026-03-15T00:00:00Z
+	AnchorDate *time.Time `json:"anchor_date,omitempty"`
+	// example: 2026-01-01T00:00:00Z
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// example: 2026-03-31T00:00:00Z
+	EndDate *time.Time `json:"end_date,omitempty"`
+	// example: EUR
+	Currency *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
 }
 
 func (h *HTTP) create(c echo.Context) error {
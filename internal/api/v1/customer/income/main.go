@@ -1,11 +1,14 @@
 package income
 
 import (
+	"dullahan/internal/db/exchangerate"
 	"dullahan/internal/model"
+	"dullahan/internal/money"
 
 	"github.com/M15t/ghoul/pkg/rbac"
 	"github.com/M15t/ghoul/pkg/server"
 	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
 
 	structutil "github.com/M15t/ghoul/pkg/util/struct"
 )
@@ -16,11 +19,33 @@ func (s *Income) Create(c echo.Context, authUsr *model.AuthCustomer, data Creati
 		return nil, err
 	}
 
+	if data.Currency != "" {
+		ok, err := s.db.ExchangeRate.Convertible(s.db.GDB, data.Currency, authUsr.SessionID)
+		if err != nil {
+			return nil, server.NewHTTPInternalError("Error checking exchange rates").SetInternal(err)
+		}
+		if !ok {
+			return nil, exchangerate.ErrNotFound
+		}
+	}
+
 	rec := &model.Income{
 		Name:      data.Name,
 		Type:      data.Type,
-		Amount:    data.Amount,
+		Amount:    money.FromFloat(data.Amount),
+		IsGross:   data.IsGross,
 		SessionID: authUsr.SessionID,
+		Currency:  data.Currency,
+
+		Schedule: model.NewSchedule(data.Frequency, data.AnchorDate),
+
+		AnnualRaiseRate: data.AnnualRaiseRate,
+		RaiseMonth:      data.RaiseMonth,
+	}
+
+	rec.SetPeriod(data.StartDate, data.EndDate)
+	if !rec.IsValidPeriod() {
+		return nil, ErrInvalidIncomePeriod
 	}
 
 	if err := s.db.Income.Create(s.db.GDB, rec); err != nil {
@@ -41,8 +66,40 @@ func (s *Income) Update(c echo.Context, authUsr *model.AuthCustomer, id int64, d
 		return nil, ErrIncomeNotFound
 	}
 
+	if data.Currency != nil && *data.Currency != "" {
+		ok, err := s.db.ExchangeRate.Convertible(s.db.GDB, *data.Currency, authUsr.SessionID)
+		if err != nil {
+			return nil, server.NewHTTPInternalError("Error checking exchange rates").SetInternal(err)
+		}
+		if !ok {
+			return nil, exchangerate.ErrNotFound
+		}
+	}
+
+	// * an item must not end before it starts
+	if data.StartDate != nil || data.EndDate != nil {
+		current := new(model.Income)
+		if err := s.db.Income.View(s.db.GDB, current, id); err != nil {
+			return nil, ErrIncomeNotFound.SetInternal(err)
+		}
+
+		current.SetPeriod(data.StartDate, data.EndDate)
+		if !current.IsValidPeriod() {
+			return nil, ErrInvalidIncomePeriod
+		}
+	}
+
 	// optimistic update
 	updates := structutil.ToMap(data)
+	if data.AnchorDate != nil {
+		updates["anchor_date"] = datatypes.Date(*data.AnchorDate)
+	}
+	if data.StartDate != nil {
+		updates["start_date"] = datatypes.Date(*data.StartDate)
+	}
+	if data.EndDate != nil {
+		updates["end_date"] = datatypes.Date(*data.EndDate)
+	}
 	if err := s.db.Income.Update(s.db.GDB, updates, id); err != nil {
 		return nil, server.NewHTTPInternalError("Error updating purchase").SetInternal(err)
 	}
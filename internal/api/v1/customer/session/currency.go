@@ -0,0 +1,141 @@
+package session
+
+import (
+	"dullahan/internal/db/exchangerate"
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"dullahan/internal/tax"
+	"time"
+
+	"github.com/M15t/ghoul/pkg/server"
+)
+
+// convertAmounts fills the exchange rate of every item to the session currency with its converted amounts
+func (s *Session) convertAmounts(rec *model.Session) error {
+	table, err := s.db.ExchangeRate.Table(s.db.GDB)
+	if err != nil {
+		return server.NewHTTPInternalError("Error loading exchange rates").SetInternal(err)
+	}
+
+	base, now := sessionCurrency(rec), time.Now()
+	rateOf := func(currency string) (float64, error) {
+		if currency == "" {
+			return 1, nil
+		}
+
+		rate, err := table.Rate(currency, base, now)
+		if err != nil {
+			return 0, exchangerate.ErrNotFound.SetInternal(err)
+		}
+
+		return rate, nil
+	}
+
+	// * the tax rule amounts are in the local currency of its region
+	if rule, ruleErr := tax.LookupRule(rec.TaxRegion, rec.TaxRuleVersion); ruleErr == nil {
+		if rec.TaxExchangeRate, err = rateOf(rule.Currency); err != nil {
+			return err
+		}
+	}
+
+	for _, income := range rec.Incomes {
+		if income.ExchangeRate, err = rateOf(income.Currency); err != nil {
+			return err
+		}
+		if income.ConvertedAmount, err = convertedAmount(income.Amount, income.ExchangeRate); err != nil {
+			return err
+		}
+	}
+	for _, expense := range rec.Expenses {
+		if expense.ExchangeRate, err = rateOf(expense.Currency); err != nil {
+			return err
+		}
+		if expense.ConvertedAmount, err = convertedAmount(expense.Amount, expense.ExchangeRate); err != nil {
+			return err
+		}
+	}
+	for _, debt := range rec.Debts {
+		if debt.ExchangeRate, err = rateOf(debt.Currency); err != nil {
+			return err
+		}
+		if debt.ConvertedRemainingAmount, err = convertedAmount(debt.RemainingAmount, debt.ExchangeRate); err != nil {
+			return err
+		}
+		if debt.ConvertedMonthlyPayment, err = convertedAmount(debt.MonthlyPayment, debt.ExchangeRate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sessionCurrency returns the currency totals and forecasts of the session are in
+func sessionCurrency(rec *model.Session) string {
+	if rec.Currency == "" {
+		return model.CurrencyUSD
+	}
+
+	return rec.Currency
+}
+
+// convertedAmount returns the amount in the session currency to the cent, an error when it overflows
+func convertedAmount(amount money.Decimal, rate float64) (float64, error) {
+	if rate == 0 {
+		return amount.Round(money.Cents).Float64(), nil
+	}
+
+	converted, err := amount.Mul(rate)
+	if err != nil {
+		return 0, ErrConvertedAmountOutOfRange.SetInternal(err)
+	}
+
+	return converted.Round(money.Cents).Float64(), nil
+}
+
+// inSessionCurrency returns the amount at the exchange rate, as is when not converted yet.
+// The amounts are checked by convertAmounts, so an overflow is clamped here.
+func inSessionCurrency(amount money.Decimal, rate float64) money.Decimal {
+	if rate == 0 {
+		return amount
+	}
+
+	converted, _ := amount.Mul(rate)
+	return converted
+}
+
+// convertedIncomes returns copies of the incomes with their amounts in the session currency
+func convertedIncomes(incomes []*model.Income) []*model.Income {
+	converted := make([]*model.Income, len(incomes))
+	for i, income := range incomes {
+		v := *income
+		v.Amount = inSessionCurrency(v.Amount, v.ExchangeRate)
+		converted[i] = &v
+	}
+
+	return converted
+}
+
+// convertedExpenses returns copies of the expenses with their amounts in the session currency
+func convertedExpenses(expenses []*model.Expense) []*model.Expense {
+	converted := make([]*model.Expense, len(expenses))
+	for i, expense := range expenses {
+		v := *expense
+		v.Amount = inSessionCurrency(v.Amount, v.ExchangeRate)
+		converted[i] = &v
+	}
+
+	return converted
+}
+
+// convertedDebts returns copies of the debts with their amounts in the session currency
+func convertedDebts(debts []*model.Debt) []*model.Debt {
+	converted := make([]*model.Debt, len(debts))
+	for i, debt := range debts {
+		v := *debt
+		v.RemainingAmount = inSessionCurrency(v.RemainingAmount, v.ExchangeRate)
+		v.MonthlyPayment = inSessionCurrency(v.MonthlyPayment, v.ExchangeRate)
+		converted[i] = &v
+	}
+
+	return converted
+}
@@ -8,26 +8,20 @@ import (
 
 // custom errors
 var (
-	ErrSessionNotFound = server.NewHTTPError(http.StatusBadRequest, "SESSION_NOTFOUND", "Session not found")
-
-	// Months = []int{12, 24, 36, 48, 60, 72, 84, 96, 108, 120}
-
-	// Quarters = []int{1, 3, 6, 9, 12, 15, 18, 21, 24, 27, 30, 33, 36, 39, 42, 45, 48, 51, 54, 57, 60, 63, 66, 69, 72, 75, 78, 81, 84, 87, 90, 93, 96, 99, 102, 105, 108, 111, 114, 117, 120}
+	ErrSessionNotFound           = server.NewHTTPError(http.StatusBadRequest, "SESSION_NOTFOUND", "Session not found")
+	ErrOverrideItemNotFound      = server.NewHTTPError(http.StatusBadRequest, "OVERRIDE_ITEM_NOTFOUND", "Item to modify or remove not found")
+	ErrOverrideMissingItemID     = server.NewHTTPError(http.StatusBadRequest, "OVERRIDE_MISSING_ITEM_ID", "Item to remove must have an id")
+	ErrScenarioNotFound          = server.NewHTTPError(http.StatusBadRequest, "SCENARIO_NOTFOUND", "Scenario not found")
+	ErrTaxRuleNotFound           = server.NewHTTPError(http.StatusBadRequest, "TAX_RULE_NOTFOUND", "Tax rules not found for the region and version")
+	ErrConvertedAmountOutOfRange = server.NewHTTPError(http.StatusBadRequest, "CONVERTED_AMOUNT_OUT_OF_RANGE", "Amount is too large once converted to the session currency")
+	ErrOverrideMissingAnchorDate = server.NewHTTPError(http.StatusBadRequest, "OVERRIDE_MISSING_ANCHOR_DATE", "One-time item must have an anchor date")
 )
 
-// Const
+// Custom const
 const (
-	YearsForCalculation = 5
-	CustomMonth         = 12
-	CustomDay           = 31
-
-	UserBudgetIncreasementRate = 0.00966
-	BankruptCeil               = 200.00
-	EmergencyFundRate          = 6.00
-	RainydayFundRate           = 3.00
-	FunFundRate                = 0.2
-
-	RetirementPlanRate = 10.00 // years
+	MaxComparedScenarios = 5
+	ScenarioNameBaseline = "Baseline"
 
-	MillionaireRate = 1000000.00 // 1 million dollars
+	// * budget policy columns are prefixed in sessions table
+	BudgetPolicyColumnPrefix = "policy_"
 )
@@ -0,0 +1,34 @@
+package session
+
+import (
+	"dullahan/internal/forecast"
+	"dullahan/internal/model"
+	"time"
+
+	"github.com/M15t/ghoul/pkg/server"
+	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
+)
+
+// ListHealthScores returns the daily health scores of the current session, oldest first
+func (s *Session) ListHealthScores(c echo.Context, authUsr *model.AuthCustomer) ([]*model.HealthScore, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	var data []*model.HealthScore
+	if err := s.db.HealthScore.ListBySession(s.db.GDB, &data, authUsr.SessionID); err != nil {
+		return nil, server.NewHTTPInternalError("Error listing health scores").SetInternal(err)
+	}
+
+	return data, nil
+}
+
+// recordHealth fills the health score of the session and saves it as the score of the day
+func (s *Session) recordHealth(rec *model.Session) error {
+	rec.Health = forecast.Health(newForecastInput(rec))
+	rec.Health.SessionID = rec.ID
+	rec.Health.RecordedOn = datatypes.Date(time.Now())
+
+	return s.db.HealthScore.Upsert(s.db.GDB, rec.Health)
+}
@@ -3,7 +3,9 @@ package session
 import (
 	"dullahan/internal/model"
 	"net/http"
+	"time"
 
+	httputil "github.com/M15t/ghoul/pkg/util/http"
 	"github.com/labstack/echo/v4"
 )
 
@@ -15,10 +17,24 @@ type HTTP struct {
 
 // Service represents session application interface
 type Service interface {
-	Me(c echo.Context, authUsr *model.AuthCustomer) (*model.Session, error)
+	Me(c echo.Context, authUsr *model.AuthCustomer, data ChartData) (*model.Session, error)
 	Update(c echo.Context, authUsr *model.AuthCustomer, data UpdateData) error
-	GenerateLineChartData(c echo.Context, authUsr *model.AuthCustomer) (*LineChartDataResponse, error)
-	GenerateTimelineData(c echo.Context, authUsr *model.AuthCustomer) ([]*model.Timeline, error)
+	GenerateLineChartData(c echo.Context, authUsr *model.AuthCustomer, data ChartData) (*LineChartDataResponse, error)
+	GenerateTimelineData(c echo.Context, authUsr *model.AuthCustomer, data ChartData) (*TimelineChartDataResponse, error)
+	CompareDebtStrategies(c echo.Context, authUsr *model.AuthCustomer) ([]*model.StrategyComparison, error)
+	Sensitivity(c echo.Context, authUsr *model.AuthCustomer, data SensitivityData) (*SensitivityResponse, error)
+	Simulate(c echo.Context, authUsr *model.AuthCustomer, data SimulationData) (*SimulationResponse, error)
+	ExpenseBreakdown(c echo.Context, authUsr *model.AuthCustomer) (*ExpenseBreakdownResponse, error)
+	ViewBudgetPolicy(c echo.Context, authUsr *model.AuthCustomer) (*model.BudgetPolicy, error)
+	UpdateBudgetPolicy(c echo.Context, authUsr *model.AuthCustomer, data BudgetPolicyData) (*model.BudgetPolicy, error)
+	ListHealthScores(c echo.Context, authUsr *model.AuthCustomer) ([]*model.HealthScore, error)
+
+	ListScenarios(c echo.Context, authUsr *model.AuthCustomer) ([]*model.Scenario, error)
+	CreateScenario(c echo.Context, authUsr *model.AuthCustomer, data ScenarioCreationData) (*model.Scenario, error)
+	ViewScenario(c echo.Context, authUsr *model.AuthCustomer, id int64) (*model.Scenario, error)
+	UpdateScenario(c echo.Context, authUsr *model.AuthCustomer, id int64, data ScenarioUpdateData) (*model.Scenario, error)
+	DeleteScenario(c echo.Context, authUsr *model.AuthCustomer, id int64) error
+	CompareScenarios(c echo.Context, authUsr *model.AuthCustomer, data ScenarioCompareData) (*ScenarioComparisonResponse, error)
 }
 
 // NewHTTP creates new card http service
@@ -28,6 +44,11 @@ func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
 	// swagger:operation GET /v1/customer/me customer-me customerMe
 	// ---
 	// summary: Return current session
+	// parameters:
+	// - name: years
+	//   in: query
+	//   description: forecast horizon in years, from 1 to 50, 5 by default
+	//   type: integer
 	// responses:
 	//   "200":
 	//     description: Current session
@@ -44,6 +65,31 @@ func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
 	// swagger:operation GET /v1/customer/me/generate-line-chart customer-me customerMeGenerateLineChart
 	// ---
 	// summary: Generate line chart data
+	// parameters:
+	// - name: years
+	//   in: query
+	//   description: forecast horizon in years, from 1 to 50, 5 by default
+	//   type: integer
+	// - name: granularity
+	//   in: query
+	//   description: chart step, one of MONTHLY, QUARTERLY, YEARLY, MONTHLY by default
+	//   type: string
+	// - name: values
+	//   in: query
+	//   description: NOMINAL or REAL for inflation adjusted amounts, NOMINAL by default
+	//   type: string
+	// - name: simulations
+	//   in: query
+	//   description: number of randomized paths, from 10 to 5000, returns p10/p50/p90 asset bands and milestone probabilities
+	//   type: integer
+	// - name: seed
+	//   in: query
+	//   description: seed of the randomized paths, the same seed gives the same outcome
+	//   type: integer
+	// - name: by
+	//   in: query
+	//   description: date the milestone probabilities are measured by, YYYY-MM-DD, the end of the horizon by default
+	//   type: string
 	// responses:
 	//   "200":
 	//     description: Line chart data
@@ -60,6 +106,15 @@ func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
 	// swagger:operation GET /v1/customer/me/generate-timeline-chart customer-me customerMeGenerateTimelineChart
 	// ---
 	// summary: Generate timeline chart data
+	// parameters:
+	// - name: years
+	//   in: query
+	//   description: forecast horizon in years, from 1 to 50, 5 by default
+	//   type: integer
+	// - name: granularity
+	//   in: query
+	//   description: chart step, one of MONTHLY, QUARTERLY, YEARLY, MONTHLY by default
+	//   type: string
 	// responses:
 	//   "200":
 	//     description: Timeline chart data
@@ -73,6 +128,307 @@ func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
 	//     "$ref": "#/responses/errDetails"
 	eg.GET("/generate-timeline-chart", h.generateTimelineChart)
 
+	// swagger:operation GET /v1/customer/me/expense-breakdown customer-me customerMeExpenseBreakdown
+	// ---
+	// summary: Returns the monthly expenses split by essential and non essential
+	// responses:
+	//   "200":
+	//     description: Expense totals and percentages per type
+	//     schema:
+	//       "$ref": "#/definitions/ExpenseBreakdownResponse"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/expense-breakdown", h.expenseBreakdown)
+
+	// swagger:operation GET /v1/customer/me/debt-strategies customer-me customerMeCompareDebtStrategies
+	// ---
+	// summary: Compare the debt payoff strategies
+	// responses:
+	//   "200":
+	//     description: Outcome of each strategy
+	//     schema:
+	//       "$ref": "#/definitions/DebtStrategiesResponse"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/debt-strategies", h.compareDebtStrategies)
+
+	// swagger:operation GET /v1/customer/me/sensitivity customer-me customerMeSensitivity
+	// ---
+	// summary: Move each forecast input one step up and down, and rank how far the milestones shift
+	// parameters:
+	// - name: years
+	//   in: query
+	//   description: forecast horizon in years, from 1 to 50, 5 by default
+	//   type: integer
+	// - name: step_type
+	//   in: query
+	//   description: PERCENT of each input or AMOUNT in the session currency, PERCENT by default. Amount steps move the return rate by one percentage point
+	//   type: string
+	// - name: step
+	//   in: query
+	//   description: size of the step, 10 percent or 100 in the session currency by default
+	//   type: number
+	// responses:
+	//   "200":
+	//     description: Inputs by impact, the biggest first
+	//     schema:
+	//       "$ref": "#/definitions/SensitivityResponse"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/sensitivity", h.sensitivity)
+
+	// swagger:operation POST /v1/customer/me/simulate customer-me customerMeSimulate
+	// ---
+	// summary: Run a what-if forecast without saving anything
+	// parameters:
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerMeSimulationData"
+	// responses:
+	//   "200":
+	//     description: Forecast of the session merged with the overrides
+	//     schema:
+	//       "$ref": "#/definitions/SimulationResponse"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.POST("/simulate", h.simulate)
+
+	// swagger:operation GET /v1/customer/me/budget-policy customer-me customerMeBudgetPolicyView
+	// ---
+	// summary: Return the budget policy of the current session
+	// responses:
+	//   "200":
+	//     description: Budget policy
+	//     schema:
+	//       "$ref": "#/definitions/BudgetPolicy"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/budget-policy", h.viewBudgetPolicy)
+
+	// swagger:operation PATCH /v1/customer/me/budget-policy customer-me customerMeBudgetPolicyUpdate
+	// ---
+	// summary: Update the budget policy of the current session
+	// parameters:
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerMeBudgetPolicyData"
+	// responses:
+	//   "200":
+	//     description: The updated budget policy
+	//     schema:
+	//       "$ref": "#/definitions/BudgetPolicy"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.PATCH("/budget-policy", h.updateBudgetPolicy)
+
+	// swagger:operation GET /v1/customer/me/health-scores customer-me customerMeHealthScores
+	// ---
+	// summary: Returns the daily health scores of the current session, oldest first
+	// responses:
+	//   "200":
+	//     description: List of health scores
+	//     schema:
+	//       "$ref": "#/definitions/HealthScoreListResponse"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/health-scores", h.listHealthScores)
+
+	// swagger:operation GET /v1/customer/me/scenarios customer-me-scenarios customerMeScenarioList
+	// ---
+	// summary: Returns the scenarios of the current session
+	// responses:
+	//   "200":
+	//     description: List of scenarios
+	//     schema:
+	//       "$ref": "#/definitions/ScenarioListResponse"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/scenarios", h.listScenarios)
+
+	// swagger:operation GET /v1/customer/me/scenarios/compare customer-me-scenarios customerMeScenarioCompare
+	// ---
+	// summary: Compares the baseline with up to 5 scenarios, nothing is saved
+	// parameters:
+	// - name: ids
+	//   in: query
+	//   description: scenarios to compare, the first 5 when omitted
+	//   type: array
+	//   items:
+	//     type: integer
+	// - name: years
+	//   in: query
+	//   description: forecast horizon in years, from 1 to 50, 5 by default
+	//   type: integer
+	// - name: granularity
+	//   in: query
+	//   description: chart step, one of MONTHLY, QUARTERLY, YEARLY, MONTHLY by default
+	//   type: string
+	// - name: values
+	//   in: query
+	//   description: NOMINAL or REAL for inflation adjusted amounts, NOMINAL by default
+	//   type: string
+	// responses:
+	//   "200":
+	//     description: Aligned series and milestone dates of each scenario
+	//     schema:
+	//       "$ref": "#/definitions/ScenarioComparisonResponse"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/scenarios/compare", h.compareScenarios)
+
+	// swagger:operation POST /v1/customer/me/scenarios customer-me-scenarios customerMeScenarioCreate
+	// ---
+	// summary: Creates a new scenario
+	// parameters:
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerMeScenarioCreationData"
+	// responses:
+	//   "200":
+	//     description: The new scenario
+	//     schema:
+	//       "$ref": "#/definitions/Scenario"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.POST("/scenarios", h.createScenario)
+
+	// swagger:operation GET /v1/customer/me/scenarios/{id} customer-me-scenarios customerMeScenarioView
+	// ---
+	// summary: Returns a single scenario
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of scenario
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: The scenario
+	//     schema:
+	//       "$ref": "#/definitions/Scenario"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.GET("/scenarios/:id", h.viewScenario)
+
+	// swagger:operation PATCH /v1/customer/me/scenarios/{id} customer-me-scenarios customerMeScenarioUpdate
+	// ---
+	// summary: Updates a scenario, the overrides are replaced as a whole
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of scenario
+	//   type: integer
+	//   required: true
+	// - name: request
+	//   in: body
+	//   description: Request body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomerMeScenarioUpdateData"
+	// responses:
+	//   "200":
+	//     description: The updated scenario
+	//     schema:
+	//       "$ref": "#/definitions/Scenario"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.PATCH("/scenarios/:id", h.updateScenario)
+
+	// swagger:operation DELETE /v1/customer/me/scenarios/{id} customer-me-scenarios customerMeScenarioDelete
+	// ---
+	// summary: Deletes a scenario
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of scenario
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/ok"
+	//   "400":
+	//     "$ref": "#/responses/errDetails"
+	//   "401":
+	//     "$ref": "#/responses/errDetails"
+	//   "403":
+	//     "$ref": "#/responses/errDetails"
+	//   "500":
+	//     "$ref": "#/responses/errDetails"
+	eg.DELETE("/scenarios/:id", h.deleteScenario)
+
 	// swagger:operation PATCH /v1/customer/me customer-me customerMeUpdate
 	// ---
 	// summary: Update current session
@@ -103,24 +459,356 @@ func NewHTTP(svc Service, auth model.Auth, eg *echo.Group) {
 // swagger:model CustomerMeUpdateData
 type UpdateData struct {
 	// example: 10000
-	CurrentBalance float64 `json:"current_balance" validate:"required,gte=0"`
+	CurrentBalance *float64 `json:"current_balance,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
+	// example: AVALANCHE
+	DebtStrategy *string `json:"debt_strategy,omitempty" validate:"omitempty,oneof=AVALANCHE SNOWBALL CUSTOM"` // AVALANCHE, SNOWBALL, CUSTOM
+	// ISO 4217 code of the currency totals and forecasts are in
+	// example: EUR
+	Currency *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	// example: BALANCED
+	RiskProfile *string `json:"risk_profile,omitempty" validate:"omitempty,oneof=CONSERVATIVE BALANCED AGGRESSIVE"` // CONSERVATIVE, BALANCED, AGGRESSIVE
+	// Expected annual return in percent, the preset of the risk profile when omitted
+	// example: 7.5
+	ExpectedAnnualReturn *float64 `json:"expected_annual_return,omitempty" validate:"omitempty,gte=-50,lte=100"`
+	// Expected annual volatility in percent, the preset of the risk profile when omitted
+	// example: 12
+	ExpectedVolatility *float64 `json:"expected_volatility,omitempty" validate:"omitempty,gte=0,lte=100"`
+	// Annual inflation rate in percent, applied to expenses
+	// example: 3
+	InflationRate *float64 `json:"inflation_rate,omitempty" validate:"omitempty,gte=-10,lte=50"`
+	// example: 1990-05-20T00:00:00Z
+	BirthDate *time.Time `json:"birth_date,omitempty"`
+	// example: 60
+	RetirementAge *int `json:"retirement_age,omitempty" validate:"omitempty,min=30,max=100"`
+	// example: 90
+	LifeExpectancy *int `json:"life_expectancy,omitempty" validate:"omitempty,min=40,max=120"`
+	// Annual safe withdrawal rate in percent
+	// example: 4
+	SafeWithdrawalRate *float64 `json:"safe_withdrawal_rate,omitempty" validate:"omitempty,gte=1,lte=10"`
+	// Region of the tax rules gross incomes are taxed by, empty for no tax
+	// example: US
+	TaxRegion *string `json:"tax_region,omitempty" validate:"omitempty,max=10"`
+	// Version of the tax rules, the latest one when empty
+	// example: 2026
+	TaxRuleVersion *string `json:"tax_rule_version,omitempty" validate:"omitempty,max=20"`
+}
+
+// ChartData contains forecast options from query string
+type ChartData struct {
+	// example: 10
+	Years int `json:"years,omitempty" query:"years" validate:"omitempty,min=1,max=50"`
+	// example: QUARTERLY
+	Granularity string `json:"granularity,omitempty" query:"granularity" validate:"omitempty,oneof=MONTHLY QUARTERLY YEARLY"`
+	// example: REAL
+	Values string `json:"values,omitempty" query:"values" validate:"omitempty,oneof=NOMINAL REAL"`
+	// Number of randomized paths, deterministic forecast only when omitted
+	// example: 500
+	Simulations int `json:"simulations,omitempty" query:"simulations" validate:"omitempty,min=10,max=5000"`
+	// Seed of the randomized paths, a random one when omitted
+	// example: 42
+	Seed int64 `json:"seed,omitempty" query:"seed"`
+	// Date the milestone probabilities are measured by, the end of the horizon when omitted
+	// example: 2030-12-31
+	By string `json:"by,omitempty" query:"by" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// BudgetPolicyData contains budget policy data from json request
+// swagger:model CustomerMeBudgetPolicyData
+type BudgetPolicyData struct {
+	// Restore the defaults before applying the other fields
+	// example: false
+	Reset bool `json:"reset,omitempty"`
+	// Months of essential expenses in the emergency fund
+	// example: 12
+	EmergencyFundMonths *float64 `json:"emergency_fund_months,omitempty" validate:"omitempty,gte=0,lte=36"`
+	// Months of essential expenses in the rainy day fund
+	// example: 3
+	RainydayFundMonths *float64 `json:"rainyday_fund_months,omitempty" validate:"omitempty,gte=0,lte=36"`
+	// Percent of the monthly net flow for the fun fund
+	// example: 20
+	FunFundRate *float64 `json:"fun_fund_rate,omitempty" validate:"omitempty,gte=0,lte=100"`
+	// Most set aside for fun each month, 0 for no cap
+	// example: 500
+	FunFundCap *float64 `json:"fun_fund_cap,omitempty" validate:"omitempty,gte=0,lte=1000000"`
+	// Whether the fun fund is spent each month or rolled over
+	// example: ROLLOVER
+	FunFundMode *string `json:"fun_fund_mode,omitempty" validate:"omitempty,oneof=SPEND ROLLOVER"`
+	// Years of essential expenses in the retirement plan
+	// example: 10
+	RetirementPlanYears *float64 `json:"retirement_plan_years,omitempty" validate:"omitempty,gte=1,lte=60"`
+	// Monthly net flow below which the status is paycheck to paycheck
+	// example: 200
+	PaycheckCeil *float64 `json:"paycheck_ceil,omitempty" validate:"omitempty,gte=0,lte=100000"`
+	// Monthly net flow above this many essential expenses is good financial flexibility
+	// example: 1
+	GoodFlexibilityRate *float64 `json:"good_flexibility_rate,omitempty" validate:"omitempty,gte=0.1,lte=10"`
+	// Expenses passive incomes and portfolio yield must cover for financial freedom
+	// example: TOTAL
+	FreedomCovers *string `json:"freedom_covers,omitempty" validate:"omitempty,oneof=ESSENTIAL TOTAL"`
+}
+
+// SimulationData contains what-if overrides and forecast options from json request
+// swagger:model CustomerMeSimulationData
+type SimulationData struct {
+	Overrides
+	ChartData
+}
+
+// Overrides contains what-if changes of the session, items without id are added
+// swagger:model
+type Overrides struct {
+	UpdateData
+	BudgetPolicy *BudgetPolicyData `json:"budget_policy,omitempty"`
+
+	Incomes  []*IncomeOverride  `json:"incomes,omitempty" validate:"omitempty,dive"`
+	Expenses []*ExpenseOverride `json:"expenses,omitempty" validate:"omitempty,dive"`
+	Debts    []*DebtOverride    `json:"debts,omitempty" validate:"omitempty,dive"`
+}
+
+// IncomeOverride contains the what-if change of an income
+// swagger:model
+type IncomeOverride struct {
+	// Income to modify or remove, a new income when omitted
+	// example: 1
+	ID int64 `json:"id,omitempty"`
+	// example: false
+	Remove bool `json:"remove,omitempty"`
+	// example: Side job
+	Name *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	// example: MONTHLY
+	Type *string `json:"type,omitempty" validate:"omitempty,oneof=MONTHLY PASSIVE"`
+	// example: 500
+	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
+	// example: true
+	IsGross *bool `json:"is_gross,omitempty"`
+	// example: EUR
+	Currency *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	// example: 3
+	AnnualRaiseRate *float64 `json:"annual_raise_rate,omitempty" validate:"omitempty,gte=-100,lte=100"`
+	// example: 1
+	RaiseMonth *int `json:"raise_month,omitempty" validate:"omitempty,min=1,max=12"`
+	// example: QUARTERLY
+	Frequency *string `json:"frequency,omitempty" validate:"omitempty,oneof=WEEKLY BIWEEKLY SEMI_MONTHLY MONTHLY QUARTERLY ANNUAL ONE_TIME"`
+	// example: 2026-03-15T00:00:00Z
+	AnchorDate *time.Time `json:"anchor_date,omitempty"`
+	// example: 2026-09-01T00:00:00Z
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// example: 2027-03-31T00:00:00Z
+	EndDate *time.Time `json:"end_date,omitempty"`
+}
+
+// ExpenseOverride contains the what-if change of an expense
+// swagger:model
+type ExpenseOverride struct {
+	// Expense to modify or remove, a new expense when omitted
+	// example: 1
+	ID int64 `json:"id,omitempty"`
+	// example: true
+	Remove bool `json:"remove,omitempty"`
+	// example: Netflix
+	Name *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	// example: NON_ESSENTIAL
+	Type *string `json:"type,omitempty" validate:"omitempty,oneof=ESSENTIAL NON_ESSENTIAL"`
+	// example: 15
+	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
+	// example: EUR
+	Currency *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	// example: ANNUAL
+	Frequency *string `json:"frequency,omitempty" validate:"omitempty,oneof=WEEKLY BIWEEKLY SEMI_MONTHLY MONTHLY QUARTERLY ANNUAL ONE_TIME"`
+	// example: 2026-03-15T00:00:00Z
+	AnchorDate *time.Time `json:"anchor_date,omitempty"`
+	// example: 2026-09-01T00:00:00Z
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// example: 2027-03-31T00:00:00Z
+	EndDate *time.Time `json:"end_date,omitempty"`
+}
+
+// DebtOverride contains the what-if change of a debt
+// swagger:model
+type DebtOverride struct {
+	// Debt to modify or remove, a new debt when omitted
+	// example: 1
+	ID int64 `json:"id,omitempty"`
+	// example: false
+	Remove bool `json:"remove,omitempty"`
+	// example: Car loan
+	Name *string `json:"name,omitempty" validate:"omitempty,max=50"`
+	// example: 30000
+	RemainingAmount *float64 `json:"remaining_amount,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
+	// example: 500
+	MonthlyPayment *float64 `json:"monthly_payment,omitempty" validate:"omitempty,gte=0,lte=99999999999999"`
+	// example: 11
+	AnnualInterest *float64 `json:"annual_interest,omitempty" validate:"omitempty,gte=0"`
+	// example: FIXED
+	Type *string `json:"type,omitempty" validate:"omitempty,oneof=FIXED FIXED_AMORTIZED FLOAT FLOAT_AMORTIZED"`
+	// example: 2025-12-31T00:00:00Z
+	PaymentDeadline *time.Time `json:"payment_deadline,omitempty"`
+	// example: 1
+	Priority *int `json:"priority,omitempty" validate:"omitempty,gte=0"`
+	// example: EUR
+	Currency *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+}
+
+// SimulationResponse contains the what-if forecast
+// swagger:model
+type SimulationResponse struct {
+	Session     *model.Session           `json:"session"`
+	LineCharts  []*model.LineChart       `json:"line_charts"`
+	Timelines   []*model.Timeline        `json:"timelines"`
+	Assumptions *model.ReturnAssumptions `json:"assumptions"`
+	MonteCarlo  *MonteCarloResponse      `json:"monte_carlo,omitempty"`
+}
+
+// ScenarioCreationData contains scenario data from json request
+// swagger:model CustomerMeScenarioCreationData
+type ScenarioCreationData struct {
+	// example: Aggressive saving
+	Name      string    `json:"name" validate:"required,max=100"`
+	Overrides Overrides `json:"overrides"`
+}
+
+// ScenarioUpdateData contains scenario data from json request
+// swagger:model CustomerMeScenarioUpdateData
+type ScenarioUpdateData struct {
+	// example: Refinance
+	Name      *string    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Overrides *Overrides `json:"overrides,omitempty"`
+}
+
+// ScenarioCompareData contains compare options from query string
+type ScenarioCompareData struct {
+	ChartData
+	IDs []int64 `json:"ids,omitempty" query:"ids" validate:"omitempty,max=5,unique"`
+}
+
+// HealthScoreListResponse contains the daily health scores of a session
+// swagger:model
+type HealthScoreListResponse struct {
+	HealthScores []*model.HealthScore `json:"data"`
+}
+
+// ScenarioListResponse contains the scenarios of a session
+// swagger:model
+type ScenarioListResponse struct {
+	Scenarios []*model.Scenario `json:"data"`
+}
+
+// ScenarioComparisonResponse contains the baseline and the scenarios side by side
+// swagger:model
+type ScenarioComparisonResponse struct {
+	Keys       []string          `json:"keys"`
+	Series     []*ScenarioSeries `json:"series"` // * baseline first, aligned with keys
+	Milestones []*MilestoneDiff  `json:"milestones"`
+}
+
+// ScenarioSeries contains the forecast of a scenario
+// swagger:model
+type ScenarioSeries struct {
+	ScenarioID     int64     `json:"scenario_id"` // * 0 for the baseline
+	Name           string    `json:"name"`
+	Status         string    `json:"status"`
+	MonthlyNetFlow float64   `json:"monthly_net_flow"`
+	Assets         []float64 `json:"assets"`
+	Debts          []float64 `json:"debts"`
+}
+
+// MilestoneDiff contains the date of a milestone in the baseline and each scenario
+// swagger:model
+type MilestoneDiff struct {
+	Milestone string           `json:"milestone"`
+	Baseline  string           `json:"baseline"`
+	Scenarios []*MilestoneDate `json:"scenarios"`
+}
+
+// MilestoneDate contains the date of a milestone in a scenario
+// swagger:model
+type MilestoneDate struct {
+	ScenarioID int64  `json:"scenario_id"`
+	Name       string `json:"name"`
+	Date       string `json:"date"`
+	MonthsDiff *int   `json:"months_diff"` // * later than the baseline when positive, null when either is never reached
 }
 
 // LineChartDataResponse contains line chart data
 // swagger:model
 type LineChartDataResponse struct {
-	LineCharts []*model.LineChart `json:"data"`
-	Debts      []*model.Debt      `json:"debts,omitempty"`
+	LineCharts  []*model.LineChart          `json:"data"`
+	Debts       []*model.Debt               `json:"debts,omitempty"`
+	Assumptions *model.ReturnAssumptions    `json:"assumptions"`
+	MonteCarlo  *MonteCarloResponse         `json:"monte_carlo,omitempty"`
+	Retirement  *model.RetirementProjection `json:"retirement,omitempty"`
+}
+
+// MonteCarloResponse contains the outcome of randomized forecast paths
+// swagger:model
+type MonteCarloResponse struct {
+	Simulations int                           `json:"simulations"`
+	Seed        int64                         `json:"seed"`
+	Bands       []*model.AssetBand            `json:"bands"`
+	Milestones  []*model.MilestoneProbability `json:"milestones"`
 }
 
 // TimelineChartDataResponse contains timeline chart data
 // swagger:model
 type TimelineChartDataResponse struct {
-	Timelines []*model.Timeline `json:"data"`
+	Timelines   []*model.Timeline        `json:"data"`
+	Assumptions *model.ReturnAssumptions `json:"assumptions"`
+}
+
+// ExpenseBreakdownResponse contains the monthly expenses split by type
+// swagger:model
+type ExpenseBreakdownResponse struct {
+	Total float64             `json:"total"`
+	Types []*ExpenseTypeTotal `json:"data"`
+}
+
+// ExpenseTypeTotal contains the monthly expenses of a type
+// swagger:model
+type ExpenseTypeTotal struct {
+	// example: ESSENTIAL
+	Type string `json:"type"`
+	// example: 2
+	Count int `json:"count"`
+	// example: 1500
+	Total float64 `json:"total"`
+	// percent of the total expenses
+	// example: 75
+	Percentage float64 `json:"percentage"`
+}
+
+// SensitivityData contains the step the forecast inputs move by, from query string
+type SensitivityData struct {
+	// example: 10
+	Years int `json:"years,omitempty" query:"years" validate:"omitempty,min=1,max=50"`
+	// example: AMOUNT
+	StepType string `json:"step_type,omitempty" query:"step_type" validate:"omitempty,oneof=PERCENT AMOUNT"`
+	// example: 100
+	Step float64 `json:"step,omitempty" query:"step" validate:"omitempty,gt=0"`
+}
+
+// SensitivityResponse contains how far the milestones shift when each input moves
+// swagger:model
+type SensitivityResponse struct {
+	// example: PERCENT
+	StepType string `json:"step_type"`
+	// example: 10
+	Step    float64                    `json:"step"`
+	Drivers []*model.SensitivityDriver `json:"data"` // * the biggest impact first
+}
+
+// DebtStrategiesResponse contains the outcome of each debt payoff strategy
+// swagger:model
+type DebtStrategiesResponse struct {
+	Strategies []*model.StrategyComparison `json:"data"`
 }
 
 func (h *HTTP) me(c echo.Context) error {
-	resp, err := h.svc.Me(c, h.auth.Customer(c))
+	r := ChartData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.Me(c, h.auth.Customer(c), r)
 	if err != nil {
 		return err
 	}
@@ -129,21 +817,190 @@ func (h *HTTP) me(c echo.Context) error {
 }
 
 func (h *HTTP) generateLineChart(c echo.Context) error {
-	resp, err := h.svc.GenerateLineChartData(c, h.auth.Customer(c))
+	r := ChartData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.GenerateLineChartData(c, h.auth.Customer(c), r)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, LineChartDataResponse{LineCharts: resp.LineCharts, Debts: resp.Debts})
+	return c.JSON(http.StatusOK, resp)
 }
 
 func (h *HTTP) generateTimelineChart(c echo.Context) error {
-	resp, err := h.svc.GenerateTimelineData(c, h.auth.Customer(c))
+	r := ChartData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.GenerateTimelineData(c, h.auth.Customer(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) expenseBreakdown(c echo.Context) error {
+	resp, err := h.svc.ExpenseBreakdown(c, h.auth.Customer(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) compareDebtStrategies(c echo.Context) error {
+	resp, err := h.svc.CompareDebtStrategies(c, h.auth.Customer(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, DebtStrategiesResponse{Strategies: resp})
+}
+
+func (h *HTTP) sensitivity(c echo.Context) error {
+	r := SensitivityData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.Sensitivity(c, h.auth.Customer(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) viewBudgetPolicy(c echo.Context) error {
+	resp, err := h.svc.ViewBudgetPolicy(c, h.auth.Customer(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) updateBudgetPolicy(c echo.Context) error {
+	r := BudgetPolicyData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.UpdateBudgetPolicy(c, h.auth.Customer(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) simulate(c echo.Context) error {
+	r := SimulationData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.Simulate(c, h.auth.Customer(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) listHealthScores(c echo.Context) error {
+	resp, err := h.svc.ListHealthScores(c, h.auth.Customer(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, HealthScoreListResponse{HealthScores: resp})
+}
+
+func (h *HTTP) listScenarios(c echo.Context) error {
+	resp, err := h.svc.ListScenarios(c, h.auth.Customer(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ScenarioListResponse{Scenarios: resp})
+}
+
+func (h *HTTP) compareScenarios(c echo.Context) error {
+	r := ScenarioCompareData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CompareScenarios(c, h.auth.Customer(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) createScenario(c echo.Context) error {
+	r := ScenarioCreationData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateScenario(c, h.auth.Customer(c), r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) viewScenario(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.svc.ViewScenario(c, h.auth.Customer(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) updateScenario(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
+	if err != nil {
+		return err
+	}
+	r := ScenarioUpdateData{}
+	if err := c.Bind(&r); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.UpdateScenario(c, h.auth.Customer(c), id, r)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HTTP) deleteScenario(c echo.Context) error {
+	id, err := httputil.ReqIDint64(c)
 	if err != nil {
 		return err
 	}
+	if err := h.svc.DeleteScenario(c, h.auth.Customer(c), id); err != nil {
+		return err
+	}
 
-	return c.JSON(http.StatusOK, TimelineChartDataResponse{Timelines: resp})
+	return c.NoContent(http.StatusNoContent)
 }
 
 func (h *HTTP) update(c echo.Context) error {
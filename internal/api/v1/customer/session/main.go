@@ -1,37 +1,48 @@
 package session
 
 import (
+	"dullahan/internal/forecast"
 	"dullahan/internal/model"
-	"fmt"
-	"sort"
+	"dullahan/internal/tax"
 	"time"
 
 	"github.com/M15t/ghoul/pkg/rbac"
 	"github.com/M15t/ghoul/pkg/server"
+	structutil "github.com/M15t/ghoul/pkg/util/struct"
 	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
 // Me returns the current session information
-func (s *Session) Me(c echo.Context, authUsr *model.AuthCustomer) (*model.Session, error) {
+func (s *Session) Me(c echo.Context, authUsr *model.AuthCustomer, data ChartData) (*model.Session, error) {
 	if err := s.enforce(authUsr, model.ActionView); err != nil {
 		return nil, err
 	}
 
-	rec := new(model.Session)
-	if err := s.db.Session.View(s.db.GDB.Preload("Incomes").Preload("Expenses").Preload("Debts", func(db *gorm.DB) *gorm.DB {
-		return db.Order("debts.annual_interest DESC,debts.remaining_amount ASC")
-	}), rec, authUsr.SessionID); err != nil {
-		return nil, ErrSessionNotFound.SetInternal(err)
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
 	}
 
 	// * recalcuate total income, expense, debt and budget cups
-	if err := s.calculateSession(s.cache, rec); err != nil {
+	if err := s.calculateSession(rec); err != nil {
 		return nil, server.NewHTTPInternalError("Error updating current session").SetInternal(err)
 	}
 
+	// * deadlines are missed as the forecast pays the debts
+	checkDeadlines(rec, forecast.Simulate(withChartData(newForecastInput(rec), data)), time.Now())
+
+	// * keep the score of the day to follow it over time
+	if err := s.recordHealth(rec); err != nil {
+		return nil, server.NewHTTPInternalError("Error saving health score").SetInternal(err)
+	}
+
 	rec.FullStatus = mappingFullStatus(rec.Status)
-	rec.NextNYears = YearsForCalculation
+	rec.NextNYears = forecast.YearsForCalculation
+	if data.Years > 0 {
+		rec.NextNYears = data.Years
+	}
 
 	return rec, nil
 }
@@ -42,125 +53,291 @@ func (s *Session) Update(c echo.Context, authUsr *model.AuthCustomer, data Updat
 		return err
 	}
 
-	return s.db.Session.Update(s.db.GDB, map[string]interface{}{
-		"current_balance": data.CurrentBalance,
-	}, authUsr.SessionID)
+	if data.TaxRegion != nil || data.TaxRuleVersion != nil {
+		rec := new(model.Session)
+		if err := s.db.Session.View(s.db.GDB, rec, authUsr.SessionID); err != nil {
+			return ErrSessionNotFound.SetInternal(err)
+		}
+
+		// * check the rules exist for the resulting region and version
+		applyUpdateData(rec, data)
+		if rec.TaxRegion != "" {
+			if _, err := tax.Lookup(rec.TaxRegion, rec.TaxRuleVersion); err != nil {
+				return ErrTaxRuleNotFound.SetInternal(err)
+			}
+		}
+
+		// * the rule currency must convert to the session currency
+		if err := s.convertAmounts(rec); err != nil {
+			return err
+		}
+	}
+
+	if data.Currency != nil {
+		rec, err := s.view(authUsr.SessionID)
+		if err != nil {
+			return err
+		}
+
+		// * every item must convert to the new currency
+		rec.Currency = *data.Currency
+		if err := s.convertAmounts(rec); err != nil {
+			return err
+		}
+	}
+
+	updates := structutil.ToMap(withRiskPreset(data))
+	if data.BirthDate != nil {
+		updates["birth_date"] = datatypes.Date(*data.BirthDate)
+	}
+
+	return s.db.Session.Update(s.db.GDB, updates, authUsr.SessionID)
 }
 
-// GenerateLineChartData generates line chart data
-func (s *Session) GenerateLineChartData(c echo.Context, authUsr *model.AuthCustomer) (*LineChartDataResponse, error) {
+// ViewBudgetPolicy returns the budget policy of the current session
+func (s *Session) ViewBudgetPolicy(c echo.Context, authUsr *model.AuthCustomer) (*model.BudgetPolicy, error) {
 	if err := s.enforce(authUsr, model.ActionView); err != nil {
 		return nil, err
 	}
 
 	rec := new(model.Session)
-	if err := s.db.Session.View(s.db.GDB.Preload("Incomes").Preload("Expenses").Preload("Debts", func(db *gorm.DB) *gorm.DB {
-		return db.Order("debts.annual_interest DESC,debts.remaining_amount ASC")
-	}), rec, authUsr.SessionID); err != nil {
+	if err := s.db.Session.View(s.db.GDB, rec, authUsr.SessionID); err != nil {
 		return nil, ErrSessionNotFound.SetInternal(err)
 	}
 
-	linecharts := s.generateLinechart(s.cache, rec)
+	return &rec.BudgetPolicy, nil
+}
+
+// UpdateBudgetPolicy updates the budget policy of the current session
+func (s *Session) UpdateBudgetPolicy(c echo.Context, authUsr *model.AuthCustomer, data BudgetPolicyData) (*model.BudgetPolicy, error) {
+	if err := s.enforce(authUsr, model.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	rec := new(model.Session)
+	if err := s.db.Session.View(s.db.GDB, rec, authUsr.SessionID); err != nil {
+		return nil, ErrSessionNotFound.SetInternal(err)
+	}
+	applyBudgetPolicy(&rec.BudgetPolicy, data)
+
+	// * zero is a valid policy value, write every column
+	policy := rec.BudgetPolicy
+	columns := map[string]interface{}{
+		BudgetPolicyColumnPrefix + "emergency_fund_months": policy.EmergencyFundMonths,
+		BudgetPolicyColumnPrefix + "rainyday_fund_months":  policy.RainydayFundMonths,
+		BudgetPolicyColumnPrefix + "fun_fund_rate":         policy.FunFundRate,
+		BudgetPolicyColumnPrefix + "fun_fund_cap":          policy.FunFundCap,
+		BudgetPolicyColumnPrefix + "fun_fund_mode":         policy.FunFundMode,
+		BudgetPolicyColumnPrefix + "retirement_plan_years": policy.RetirementPlanYears,
+		BudgetPolicyColumnPrefix + "paycheck_ceil":         policy.PaycheckCeil,
+		BudgetPolicyColumnPrefix + "good_flexibility_rate": policy.GoodFlexibilityRate,
+		BudgetPolicyColumnPrefix + "freedom_covers":        policy.FreedomCovers,
+	}
+
+	if err := s.db.Session.Update(s.db.GDB, columns, authUsr.SessionID); err != nil {
+		return nil, server.NewHTTPInternalError("Error updating budget policy").SetInternal(err)
+	}
+
+	return &rec.BudgetPolicy, nil
+}
+
+// ExpenseBreakdown returns the monthly expenses of the current session split by type
+func (s *Session) ExpenseBreakdown(c echo.Context, authUsr *model.AuthCustomer) (*ExpenseBreakdownResponse, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	node := applySnapshot(rec)
+	essential := &ExpenseTypeTotal{Type: model.ExpenseTypeEssential, Total: node.TotalEssentialExpense}
+	nonEssential := &ExpenseTypeTotal{Type: model.ExpenseTypeNonEssential, Total: node.TotalNonEssentialExpense}
+	for _, expense := range rec.Expenses {
+		if expense.Type == model.ExpenseTypeEssential {
+			essential.Count++
+		} else {
+			nonEssential.Count++
+		}
+	}
+
+	resp := &ExpenseBreakdownResponse{
+		Total: node.TotalAllExpense,
+		Types: []*ExpenseTypeTotal{essential, nonEssential},
+	}
+	if resp.Total > 0 {
+		for _, item := range resp.Types {
+			item.Percentage = s.cr.RoundFloat(item.Total / resp.Total * 100)
+		}
+	}
+
+	return resp, nil
+}
+
+// Simulate runs the forecast on the session merged with the what-if overrides, nothing is saved
+func (s *Session) Simulate(c echo.Context, authUsr *model.AuthCustomer, data SimulationData) (*SimulationResponse, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyOverrides(rec, data.Overrides); err != nil {
+		return nil, err
+	}
+	if err := s.convertAmounts(rec); err != nil {
+		return nil, err
+	}
+	rec.Debts = forecast.OrderDebts(rec.Debts, rec.DebtStrategy)
+
+	applySnapshot(rec)
+	rec.FullStatus = mappingFullStatus(rec.Status)
+	rec.NextNYears = forecast.YearsForCalculation
+	if data.Years > 0 {
+		rec.NextNYears = data.Years
+	}
+
+	in := withChartData(newForecastInput(rec), data.ChartData)
+	res := forecast.Run(in)
+	applyForecast(rec, res)
+
+	timelines := buildTimelines(rec, res, time.Now())
+	for _, timeline := range timelines {
+		timeline.Date = forecast.FormatPeriod(timeline.Datetime, data.Granularity)
+	}
+
+	resp := &SimulationResponse{
+		Session:     rec,
+		LineCharts:  res.LineCharts,
+		Timelines:   timelines,
+		Assumptions: newReturnAssumptions(rec, in),
+	}
+
+	if data.Simulations > 0 {
+		resp.MonteCarlo = runMonteCarlo(in, data.ChartData)
+	}
+
+	return resp, nil
+}
+
+// CompareDebtStrategies runs the forecast with every debt payoff strategy
+func (s *Session) CompareDebtStrategies(c echo.Context, authUsr *model.AuthCustomer) ([]*model.StrategyComparison, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
 
-	s.cache.Reset()
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return forecast.CompareStrategies(newForecastInput(rec)), nil
+}
+
+// Sensitivity ranks the forecast inputs by how far a step of each shifts the milestones
+func (s *Session) Sensitivity(c echo.Context, authUsr *model.AuthCustomer, data SensitivityData) (*SensitivityResponse, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
 
-	return &LineChartDataResponse{
-		LineCharts: linecharts,
-		Debts:      rec.Debts,
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	in := withChartData(newForecastInput(rec), ChartData{Years: data.Years})
+	step := forecast.NewStep(data.StepType, data.Step)
+
+	return &SensitivityResponse{
+		StepType: step.Type,
+		Step:     step.Size,
+		Drivers:  forecast.Sensitivity(in, step),
 	}, nil
 }
 
+// GenerateLineChartData generates line chart data
+func (s *Session) GenerateLineChartData(c echo.Context, authUsr *model.AuthCustomer, data ChartData) (*LineChartDataResponse, error) {
+	if err := s.enforce(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	in := withChartData(newForecastInput(rec), data)
+	res := forecast.Run(in)
+
+	if err := s.saveForecast(rec, res); err != nil {
+		return nil, server.NewHTTPInternalError("Error updating forecast events").SetInternal(err)
+	}
+
+	resp := &LineChartDataResponse{
+		LineCharts:  res.LineCharts,
+		Debts:       rec.Debts,
+		Assumptions: newReturnAssumptions(rec, in),
+		Retirement:  res.Retirement,
+	}
+
+	if data.Simulations > 0 {
+		resp.MonteCarlo = runMonteCarlo(in, data)
+	}
+
+	return resp, nil
+}
+
 // GenerateTimelineData generates timeline data
-func (s *Session) GenerateTimelineData(c echo.Context, authUsr *model.AuthCustomer) ([]*model.Timeline, error) {
+func (s *Session) GenerateTimelineData(c echo.Context, authUsr *model.AuthCustomer, data ChartData) (*TimelineChartDataResponse, error) {
 	if err := s.enforce(authUsr, model.ActionView); err != nil {
 		return nil, err
 	}
 
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	in := withChartData(newForecastInput(rec), data)
+	res := forecast.Run(in)
+
+	if err := s.saveForecast(rec, res); err != nil {
+		return nil, server.NewHTTPInternalError("Error updating forecast events").SetInternal(err)
+	}
+
+	timelines := buildTimelines(rec, res, time.Now())
+	for _, timeline := range timelines {
+		timeline.Date = forecast.FormatPeriod(timeline.Datetime, data.Granularity)
+	}
+
+	return &TimelineChartDataResponse{
+		Timelines:   timelines,
+		Assumptions: newReturnAssumptions(rec, in),
+	}, nil
+}
+
+// view returns the session with its incomes, expenses and debts in payoff order
+func (s *Session) view(id int64) (*model.Session, error) {
 	rec := new(model.Session)
-	if err := s.db.Session.View(s.db.GDB.Preload("Incomes").Preload("Expenses").Preload("Debts", func(db *gorm.DB) *gorm.DB {
-		return db.Order("debts.annual_interest DESC,debts.remaining_amount ASC")
-	}), rec, authUsr.SessionID); err != nil {
+	if err := s.db.Session.View(s.db.GDB.Preload("Incomes").Preload("Expenses").Preload("Goals").Preload("Debts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("debts.id ASC")
+	}).Preload("Debts.Rates", func(db *gorm.DB) *gorm.DB {
+		return db.Order("debt_rates.effective_date ASC")
+	}), rec, id); err != nil {
 		return nil, ErrSessionNotFound.SetInternal(err)
 	}
 
-	var timelines []*model.Timeline
-	var format = "Jan 2006"
-
-	for _, debt := range rec.Debts {
-		dt, _ := time.Parse(format, debt.ForecastPaidOffDate)
-		timelines = append(timelines, &model.Timeline{
-			Event:       fmt.Sprintf("Debt %s Paid Off", debt.Name),
-			Date:        debt.ForecastPaidOffDate,
-			Datetime:    dt,
-			Description: fmt.Sprintf("Your %s has been paid off. %.2f$ now will be deducted from your expenses", debt.Name, debt.MonthlyPayment),
-		})
-	}
-
-	if rec.ForecastEmergencyBudgetFilledDate != "" {
-		dt, _ := time.Parse(format, rec.ForecastEmergencyBudgetFilledDate)
-		timelines = append(timelines, &model.Timeline{
-			Event:       model.SessionTitleForecastEmergencyBudgetFilled,
-			Date:        rec.ForecastEmergencyBudgetFilledDate,
-			Datetime:    dt,
-			Description: model.SessionDescriptionForecastEmergencyBudgetFilled,
-		})
-	}
-
-	if rec.ForecastRainydayBudgetFilledDate != "" {
-		dt, _ := time.Parse(format, rec.ForecastRainydayBudgetFilledDate)
-		timelines = append(timelines, &model.Timeline{
-			Event:       model.SessionTitleForecastRainydayBudgetFilled,
-			Date:        rec.ForecastRainydayBudgetFilledDate,
-			Datetime:    dt,
-			Description: model.SessionDescriptionForecastRainydayBudgetFilled,
-		})
-	}
-
-	if rec.ForecastStartInvestingDate != "" {
-		dt, _ := time.Parse(format, rec.ForecastStartInvestingDate)
-		timelines = append(timelines, &model.Timeline{
-			Event:       model.SessionTitleForecastStartInvesting,
-			Date:        rec.ForecastStartInvestingDate,
-			Datetime:    dt,
-			Description: model.SessionDescriptionForecastStartInvesting,
-		})
-	}
-
-	if rec.ForecastFinancialFreedomDate != "" {
-		dt, _ := time.Parse(format, rec.ForecastFinancialFreedomDate)
-		timelines = append(timelines, &model.Timeline{
-			Event:       model.SessionTitleForecastFinancialFreedom,
-			Date:        rec.ForecastFinancialFreedomDate,
-			Datetime:    dt,
-			Description: model.SessionDescriptionForecastFinancialFreedom,
-		})
-	}
-
-	if rec.ForecastMillionaireDate != "" {
-		dt, _ := time.Parse(format, rec.ForecastMillionaireDate)
-		timelines = append(timelines, &model.Timeline{
-			Event:       model.SessionTitleForecastMillionaire,
-			Date:        rec.ForecastMillionaireDate,
-			Datetime:    dt,
-			Description: model.SessionDescriptionForecastMillionaire,
-		})
-	}
-
-	if rec.ForecastBankrupt != "" {
-		dt, _ := time.Parse(format, rec.ForecastBankrupt)
-		timelines = append(timelines, &model.Timeline{
-			Event:       model.SessionTitleForecastBankrupt,
-			Date:        rec.ForecastBankrupt,
-			Datetime:    dt,
-			Description: model.SessionDescriptionForecastBankrupt,
-		})
-	}
-
-	sort.Slice(timelines[:], func(i, j int) bool {
-		return timelines[i].Datetime.Before(timelines[j].Datetime)
-	})
-
-	return timelines, nil
+	if err := s.convertAmounts(rec); err != nil {
+		return nil, err
+	}
+	rec.Debts = forecast.OrderDebts(rec.Debts, rec.DebtStrategy)
+
+	return rec, nil
 }
 
 // enforce checks Session permission to perform the action
@@ -0,0 +1,291 @@
+package session
+
+import (
+	"dullahan/internal/forecast"
+	"dullahan/internal/model"
+	"encoding/json"
+	"time"
+
+	"github.com/M15t/ghoul/pkg/rbac"
+	"github.com/M15t/ghoul/pkg/server"
+	"github.com/labstack/echo/v4"
+	"gorm.io/datatypes"
+)
+
+// ListScenarios returns the scenarios of the current session
+func (s *Session) ListScenarios(c echo.Context, authUsr *model.AuthCustomer) ([]*model.Scenario, error) {
+	if err := s.enforceScenario(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	var data []*model.Scenario
+	if err := s.db.Scenario.ListBySession(s.db.GDB, &data, authUsr.SessionID); err != nil {
+		return nil, server.NewHTTPInternalError("Error listing scenarios").SetInternal(err)
+	}
+
+	return data, nil
+}
+
+// CreateScenario creates a new scenario of the current session
+func (s *Session) CreateScenario(c echo.Context, authUsr *model.AuthCustomer, data ScenarioCreationData) (*model.Scenario, error) {
+	if err := s.enforceScenario(authUsr, model.ActionCreate); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkOverrides(authUsr.SessionID, data.Overrides); err != nil {
+		return nil, err
+	}
+
+	overrides, err := json.Marshal(data.Overrides)
+	if err != nil {
+		return nil, server.NewHTTPInternalError("Error creating scenario").SetInternal(err)
+	}
+
+	rec := &model.Scenario{
+		SessionID: authUsr.SessionID,
+		Name:      data.Name,
+		Overrides: datatypes.JSON(overrides),
+	}
+
+	if err := s.db.Scenario.Create(s.db.GDB, rec); err != nil {
+		return nil, server.NewHTTPInternalError("Error creating scenario").SetInternal(err)
+	}
+
+	return rec, nil
+}
+
+// ViewScenario returns a scenario of the current session
+func (s *Session) ViewScenario(c echo.Context, authUsr *model.AuthCustomer, id int64) (*model.Scenario, error) {
+	if err := s.enforceScenario(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	return s.viewScenario(authUsr.SessionID, id)
+}
+
+// UpdateScenario updates a scenario of the current session
+func (s *Session) UpdateScenario(c echo.Context, authUsr *model.AuthCustomer, id int64, data ScenarioUpdateData) (*model.Scenario, error) {
+	if err := s.enforceScenario(authUsr, model.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	// * check legit session
+	if existed, err := s.db.Scenario.Exist(s.db.GDB, `id = ? AND session_id = ?`, id, authUsr.SessionID); err != nil || !existed {
+		return nil, ErrScenarioNotFound
+	}
+
+	updates := make(map[string]interface{})
+	if data.Name != nil {
+		updates["name"] = *data.Name
+	}
+	if data.Overrides != nil {
+		if err := s.checkOverrides(authUsr.SessionID, *data.Overrides); err != nil {
+			return nil, err
+		}
+
+		overrides, err := json.Marshal(data.Overrides)
+		if err != nil {
+			return nil, server.NewHTTPInternalError("Error updating scenario").SetInternal(err)
+		}
+		updates["overrides"] = datatypes.JSON(overrides)
+	}
+
+	if err := s.db.Scenario.Update(s.db.GDB, updates, id); err != nil {
+		return nil, server.NewHTTPInternalError("Error updating scenario").SetInternal(err)
+	}
+
+	return s.viewScenario(authUsr.SessionID, id)
+}
+
+// DeleteScenario deletes a scenario of the current session
+func (s *Session) DeleteScenario(c echo.Context, authUsr *model.AuthCustomer, id int64) error {
+	if err := s.enforceScenario(authUsr, model.ActionDelete); err != nil {
+		return err
+	}
+
+	// * check legit session
+	if existed, err := s.db.Scenario.Exist(s.db.GDB, `id = ? AND session_id = ?`, id, authUsr.SessionID); err != nil || !existed {
+		return ErrScenarioNotFound.SetInternal(err)
+	}
+
+	if err := s.db.Scenario.Delete(s.db.GDB, id); err != nil {
+		return server.NewHTTPInternalError("Error deleting scenario").SetInternal(err)
+	}
+
+	return nil
+}
+
+// CompareScenarios runs the baseline and the scenarios side by side, nothing is saved
+func (s *Session) CompareScenarios(c echo.Context, authUsr *model.AuthCustomer, data ScenarioCompareData) (*ScenarioComparisonResponse, error) {
+	if err := s.enforceScenario(authUsr, model.ActionView); err != nil {
+		return nil, err
+	}
+
+	rec, err := s.view(authUsr.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var scenarios []*model.Scenario
+	if err := s.db.Scenario.ListBySession(s.db.GDB.Limit(MaxComparedScenarios), &scenarios, authUsr.SessionID, data.IDs...); err != nil {
+		return nil, server.NewHTTPInternalError("Error listing scenarios").SetInternal(err)
+	}
+	if len(scenarios) < len(data.IDs) {
+		return nil, ErrScenarioNotFound
+	}
+
+	// * every run starts the same month so that the series line up
+	in := withChartData(newForecastInput(rec), data.ChartData)
+	keys := forecast.PeriodKeys(in)
+
+	baseline := cloneSession(rec)
+	baselineRes := runScenario(baseline, data.ChartData, in.Start)
+
+	resp := &ScenarioComparisonResponse{Keys: keys}
+	resp.Series = append(resp.Series, newScenarioSeries(0, ScenarioNameBaseline, baseline, baselineRes, keys))
+	results := make([]*forecast.Result, len(scenarios))
+	for i, scenario := range scenarios {
+		var overrides Overrides
+		if err := json.Unmarshal(scenario.Overrides, &overrides); err != nil {
+			return nil, server.NewHTTPInternalError("Error reading scenario").SetInternal(err)
+		}
+
+		merged := cloneSession(rec)
+		if err := applyOverrides(merged, overrides); err != nil {
+			return nil, err
+		}
+		if err := s.convertAmounts(merged); err != nil {
+			return nil, err
+		}
+		merged.Debts = forecast.OrderDebts(merged.Debts, merged.DebtStrategy)
+
+		results[i] = runScenario(merged, data.ChartData, in.Start)
+		resp.Series = append(resp.Series, newScenarioSeries(scenario.ID, scenario.Name, merged, results[i], keys))
+	}
+
+	for _, milestone := range forecast.EventMilestones {
+		diff := &MilestoneDiff{
+			Milestone: milestone,
+			Baseline:  baselineRes.Events.Date(milestone),
+		}
+
+		for i, scenario := range scenarios {
+			date := results[i].Events.Date(milestone)
+			diff.Scenarios = append(diff.Scenarios, &MilestoneDate{
+				ScenarioID: scenario.ID,
+				Name:       scenario.Name,
+				Date:       date,
+				MonthsDiff: monthsDiff(diff.Baseline, date),
+			})
+		}
+
+		resp.Milestones = append(resp.Milestones, diff)
+	}
+
+	return resp, nil
+}
+
+// checkOverrides merges the overrides into the session as a comparison would, so that unknown
+// items, tax rules and exchange rates are reported when the scenario is saved
+func (s *Session) checkOverrides(sessionID int64, overrides Overrides) error {
+	rec, err := s.view(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := applyOverrides(rec, overrides); err != nil {
+		return err
+	}
+
+	return s.convertAmounts(rec)
+}
+
+// viewScenario returns a scenario owned by the session
+func (s *Session) viewScenario(sessionID, id int64) (*model.Scenario, error) {
+	rec := new(model.Scenario)
+	if err := s.db.Scenario.View(s.db.GDB.Where(`session_id = ?`, sessionID), rec, id); err != nil {
+		return nil, ErrScenarioNotFound.SetInternal(err)
+	}
+
+	return rec, nil
+}
+
+// enforceScenario checks Scenario permission to perform the action
+func (s *Session) enforceScenario(authUsr *model.AuthCustomer, action string) error {
+	if !s.rbac.Enforce(authUsr.Role, model.ObjectScenario, action) {
+		return rbac.ErrForbiddenAction
+	}
+	return nil
+}
+
+// runScenario calculates the session then runs its forecast from the given month
+func runScenario(rec *model.Session, data ChartData, start time.Time) *forecast.Result {
+	applySnapshot(rec)
+
+	in := withChartData(newForecastInput(rec), data)
+	in.Start = start
+	res := forecast.Run(in)
+	applyForecast(rec, res)
+
+	return res
+}
+
+func newScenarioSeries(id int64, name string, rec *model.Session, res *forecast.Result, keys []string) *ScenarioSeries {
+	assets, debts := res.Series(keys)
+
+	return &ScenarioSeries{
+		ScenarioID:     id,
+		Name:           name,
+		Status:         rec.Status,
+		MonthlyNetFlow: rec.MonthlyNetFlow.Float64(),
+		Assets:         assets,
+		Debts:          debts,
+	}
+}
+
+// cloneSession returns a copy of the session and its items to apply overrides on
+func cloneSession(rec *model.Session) *model.Session {
+	clone := *rec
+
+	clone.Incomes = make([]*model.Income, len(rec.Incomes))
+	for i, income := range rec.Incomes {
+		v := *income
+		clone.Incomes[i] = &v
+	}
+
+	clone.Expenses = make([]*model.Expense, len(rec.Expenses))
+	for i, expense := range rec.Expenses {
+		v := *expense
+		clone.Expenses[i] = &v
+	}
+
+	clone.Debts = make([]*model.Debt, len(rec.Debts))
+	for i, debt := range rec.Debts {
+		v := *debt
+		clone.Debts[i] = &v
+	}
+
+	clone.Goals = make([]*model.Goal, len(rec.Goals))
+	for i, goal := range rec.Goals {
+		v := *goal
+		clone.Goals[i] = &v
+	}
+
+	return &clone
+}
+
+// monthsDiff returns how many months later the date is than the baseline one, nil when either is never reached
+func monthsDiff(baseline, date string) *int {
+	from, err := time.Parse(forecast.DateFormat, baseline)
+	if err != nil {
+		return nil
+	}
+	to, err := time.Parse(forecast.DateFormat, date)
+	if err != nil {
+		return nil
+	}
+
+	diff := forecast.MonthsBetween(from, to)
+
+	return &diff
+}
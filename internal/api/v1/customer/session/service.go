@@ -4,20 +4,18 @@ import (
 	"dullahan/internal/db"
 
 	"github.com/M15t/ghoul/pkg/rbac"
-	"github.com/allegro/bigcache/v3"
 )
 
 // New creates new session application service
-func New(db *db.Service, rbacSvc rbac.Intf, cr Crypter, cache *bigcache.BigCache) *Session {
-	return &Session{db: db, rbac: rbacSvc, cr: cr, cache: cache}
+func New(db *db.Service, rbacSvc rbac.Intf, cr Crypter) *Session {
+	return &Session{db: db, rbac: rbacSvc, cr: cr}
 }
 
 // Session represents latefee application service
 type Session struct {
-	db    *db.Service
-	rbac  rbac.Intf
-	cr    Crypter
-	cache *bigcache.BigCache
+	db   *db.Service
+	rbac rbac.Intf
+	cr   Crypter
 }
 
 // Crypter represents security interface
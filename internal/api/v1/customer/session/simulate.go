@@ -0,0 +1,269 @@
+package session
+
+import (
+	"dullahan/internal/api/v1/customer/expense"
+	"dullahan/internal/api/v1/customer/income"
+	"dullahan/internal/forecast"
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"dullahan/internal/tax"
+	"slices"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// applyOverrides merges the what-if changes into the session, in memory only
+func applyOverrides(rec *model.Session, data Overrides) error {
+	applyUpdateData(rec, withRiskPreset(data.UpdateData))
+	if rec.TaxRegion != "" {
+		if _, err := tax.Lookup(rec.TaxRegion, rec.TaxRuleVersion); err != nil {
+			return ErrTaxRuleNotFound.SetInternal(err)
+		}
+	}
+	if data.BudgetPolicy != nil {
+		applyBudgetPolicy(&rec.BudgetPolicy, *data.BudgetPolicy)
+	}
+
+	for _, o := range data.Incomes {
+		i := slices.IndexFunc(rec.Incomes, func(item *model.Income) bool { return item.ID == o.ID })
+		switch {
+		case o.ID == 0 && o.Remove:
+			return ErrOverrideMissingItemID
+		case o.ID == 0:
+			item := &model.Income{SessionID: rec.ID, Type: model.IncomeTypeMonthly}
+			if err := o.apply(item); err != nil {
+				return err
+			}
+			rec.Incomes = append(rec.Incomes, item)
+		case i < 0:
+			return ErrOverrideItemNotFound
+		case o.Remove:
+			rec.Incomes = slices.Delete(rec.Incomes, i, i+1)
+		default:
+			if err := o.apply(rec.Incomes[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, o := range data.Expenses {
+		i := slices.IndexFunc(rec.Expenses, func(item *model.Expense) bool { return item.ID == o.ID })
+		switch {
+		case o.ID == 0 && o.Remove:
+			return ErrOverrideMissingItemID
+		case o.ID == 0:
+			item := &model.Expense{SessionID: rec.ID, Type: model.ExpenseTypeEssential}
+			if err := o.apply(item); err != nil {
+				return err
+			}
+			rec.Expenses = append(rec.Expenses, item)
+		case i < 0:
+			return ErrOverrideItemNotFound
+		case o.Remove:
+			rec.Expenses = slices.Delete(rec.Expenses, i, i+1)
+		default:
+			if err := o.apply(rec.Expenses[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	var added int64
+	for _, o := range data.Debts {
+		i := slices.IndexFunc(rec.Debts, func(debt *model.Debt) bool { return debt.ID == o.ID })
+		switch {
+		case o.ID == 0 && o.Remove:
+			return ErrOverrideMissingItemID
+		case o.ID == 0:
+			// * negative ids keep the forecast of new debts apart
+			added--
+			debt := &model.Debt{ID: added, SessionID: rec.ID, Type: model.DebtTypeFixed}
+			o.apply(debt)
+			rec.Debts = append(rec.Debts, debt)
+		case i < 0:
+			return ErrOverrideItemNotFound
+		case o.Remove:
+			rec.Debts = slices.Delete(rec.Debts, i, i+1)
+		default:
+			o.apply(rec.Debts[i])
+		}
+	}
+
+	return nil
+}
+
+// applyUpdateData sets the given session fields, in memory only
+func applyUpdateData(rec *model.Session, data UpdateData) {
+	if data.CurrentBalance != nil {
+		rec.CurrentBalance = money.FromFloat(*data.CurrentBalance)
+	}
+	if data.DebtStrategy != nil {
+		rec.DebtStrategy = *data.DebtStrategy
+	}
+	if data.RiskProfile != nil {
+		rec.RiskProfile = *data.RiskProfile
+	}
+	if data.ExpectedAnnualReturn != nil {
+		rec.ExpectedAnnualReturn = *data.ExpectedAnnualReturn
+	}
+	if data.ExpectedVolatility != nil {
+		rec.ExpectedVolatility = *data.ExpectedVolatility
+	}
+	if data.InflationRate != nil {
+		rec.InflationRate = *data.InflationRate
+	}
+	if data.Currency != nil {
+		rec.Currency = *data.Currency
+	}
+	if data.BirthDate != nil {
+		birthDate := datatypes.Date(*data.BirthDate)
+		rec.BirthDate = &birthDate
+	}
+	if data.RetirementAge != nil {
+		rec.RetirementAge = *data.RetirementAge
+	}
+	if data.LifeExpectancy != nil {
+		rec.LifeExpectancy = *data.LifeExpectancy
+	}
+	if data.SafeWithdrawalRate != nil {
+		rec.SafeWithdrawalRate = *data.SafeWithdrawalRate
+	}
+	if data.TaxRegion != nil {
+		rec.TaxRegion = *data.TaxRegion
+	}
+	if data.TaxRuleVersion != nil {
+		rec.TaxRuleVersion = *data.TaxRuleVersion
+	}
+}
+
+// applyBudgetPolicy sets the given budget policy fields, in memory only
+func applyBudgetPolicy(policy *model.BudgetPolicy, data BudgetPolicyData) {
+	if data.Reset {
+		*policy = forecast.DefaultBudgetPolicy()
+	}
+	if data.EmergencyFundMonths != nil {
+		policy.EmergencyFundMonths = *data.EmergencyFundMonths
+	}
+	if data.RainydayFundMonths != nil {
+		policy.RainydayFundMonths = *data.RainydayFundMonths
+	}
+	if data.FunFundRate != nil {
+		policy.FunFundRate = *data.FunFundRate
+	}
+	if data.FunFundCap != nil {
+		policy.FunFundCap = *data.FunFundCap
+	}
+	if data.FunFundMode != nil {
+		policy.FunFundMode = *data.FunFundMode
+	}
+	if data.RetirementPlanYears != nil {
+		policy.RetirementPlanYears = *data.RetirementPlanYears
+	}
+	if data.PaycheckCeil != nil {
+		policy.PaycheckCeil = *data.PaycheckCeil
+	}
+	if data.GoodFlexibilityRate != nil {
+		policy.GoodFlexibilityRate = *data.GoodFlexibilityRate
+	}
+	if data.FreedomCovers != nil {
+		policy.FreedomCovers = *data.FreedomCovers
+	}
+}
+
+func (o *IncomeOverride) apply(rec *model.Income) error {
+	if o.Name != nil {
+		rec.Name = *o.Name
+	}
+	if o.Type != nil {
+		rec.Type = *o.Type
+	}
+	if o.Amount != nil {
+		rec.Amount = money.FromFloat(*o.Amount)
+	}
+	if o.IsGross != nil {
+		rec.IsGross = *o.IsGross
+	}
+	if o.Currency != nil {
+		rec.Currency = *o.Currency
+	}
+	if o.AnnualRaiseRate != nil {
+		rec.AnnualRaiseRate = *o.AnnualRaiseRate
+	}
+	if o.RaiseMonth != nil {
+		rec.RaiseMonth = *o.RaiseMonth
+	}
+	applySchedule(&rec.Schedule, o.Frequency, o.AnchorDate)
+	rec.SetPeriod(o.StartDate, o.EndDate)
+
+	return checkSchedule(rec.Schedule, income.ErrInvalidIncomePeriod)
+}
+
+func (o *ExpenseOverride) apply(rec *model.Expense) error {
+	if o.Name != nil {
+		rec.Name = *o.Name
+	}
+	if o.Type != nil {
+		rec.Type = *o.Type
+	}
+	if o.Amount != nil {
+		rec.Amount = money.FromFloat(*o.Amount)
+	}
+	if o.Currency != nil {
+		rec.Currency = *o.Currency
+	}
+	applySchedule(&rec.Schedule, o.Frequency, o.AnchorDate)
+	rec.SetPeriod(o.StartDate, o.EndDate)
+
+	return checkSchedule(rec.Schedule, expense.ErrInvalidExpensePeriod)
+}
+
+// checkSchedule validates the merged schedule as the income and expense endpoints do
+func checkSchedule(schedule model.Schedule, errInvalidPeriod error) error {
+	if schedule.Frequency == model.FrequencyOneTime && schedule.AnchorDate == nil {
+		return ErrOverrideMissingAnchorDate
+	}
+	if !schedule.IsValidPeriod() {
+		return errInvalidPeriod
+	}
+
+	return nil
+}
+
+// applySchedule sets the given schedule fields, in memory only
+func applySchedule(schedule *model.Schedule, frequency *string, anchorDate *time.Time) {
+	if frequency != nil {
+		schedule.Frequency = *frequency
+	}
+	if anchorDate != nil {
+		date := datatypes.Date(*anchorDate)
+		schedule.AnchorDate = &date
+	}
+}
+
+func (o *DebtOverride) apply(debt *model.Debt) {
+	if o.Name != nil {
+		debt.Name = *o.Name
+	}
+	if o.RemainingAmount != nil {
+		debt.RemainingAmount = money.FromFloat(*o.RemainingAmount)
+	}
+	if o.MonthlyPayment != nil {
+		debt.MonthlyPayment = money.FromFloat(*o.MonthlyPayment)
+	}
+	if o.AnnualInterest != nil {
+		debt.AnnualInterest = *o.AnnualInterest
+	}
+	if o.Type != nil {
+		debt.Type = *o.Type
+	}
+	if o.PaymentDeadline != nil {
+		debt.PaymentDeadline = datatypes.Date(*o.PaymentDeadline)
+	}
+	if o.Priority != nil {
+		debt.Priority = *o.Priority
+	}
+	if o.Currency != nil {
+		debt.Currency = *o.Currency
+	}
+}
@@ -0,0 +1,217 @@
+package session
+
+import (
+	"dullahan/internal/forecast"
+	"dullahan/internal/model"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// buildTimelines lists the forecast events of the session and its debts by date
+func buildTimelines(rec *model.Session, res *forecast.Result, now time.Time) []*model.Timeline {
+	var timelines []*model.Timeline
+	var format = forecast.DateFormat
+
+	for _, debt := range rec.Debts {
+		if debt.ForecastPaidOffDate == "" {
+			continue
+		}
+
+		dt, _ := time.Parse(format, debt.ForecastPaidOffDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       fmt.Sprintf("Debt %s Paid Off", debt.Name),
+			Date:        debt.ForecastPaidOffDate,
+			Datetime:    dt,
+			Description: fmt.Sprintf("Your %s has been paid off. %.2f$ now will be deducted from your expenses", debt.Name, debt.MonthlyPayment.Float64()),
+		})
+	}
+
+	for _, debt := range rec.Debts {
+		if !debt.IsBehindDeadline {
+			continue
+		}
+
+		dt := time.Time(debt.PaymentDeadline)
+		timelines = append(timelines, &model.Timeline{
+			Event:       fmt.Sprintf(model.DebtTitleBehindDeadline, debt.Name),
+			Date:        dt.Format(format),
+			Datetime:    dt,
+			Description: fmt.Sprintf(model.DebtDescriptionBehindDeadline, debt.MonthlyPayment.Float64(), debt.Name, debt.ForecastBalloonPayment, debt.RequiredMonthlyPayment),
+		})
+	}
+
+	// * rate resets the forecast applies while the debt is still owed
+	for _, reset := range res.Events.RateResets {
+		i := slices.IndexFunc(rec.Debts, func(debt *model.Debt) bool { return debt.ID == reset.DebtID })
+		if i < 0 {
+			continue
+		}
+
+		dt, _ := time.Parse(format, reset.Date)
+		timelines = append(timelines, &model.Timeline{
+			Event:       fmt.Sprintf("Debt %s Rate Reset", rec.Debts[i].Name),
+			Date:        reset.Date,
+			Datetime:    dt,
+			Description: fmt.Sprintf("The annual interest of your %s moves to %.2f%%, your payoff date is forecasted accordingly", rec.Debts[i].Name, reset.AnnualInterest),
+		})
+	}
+
+	// * the monthly net flow changes when a time-bounded item starts or ends
+	for _, income := range rec.Incomes {
+		amount := forecast.MonthlyAmount(inSessionCurrency(income.Amount, income.ExchangeRate).Float64(), income.Schedule)
+		timelines = append(timelines, periodTimelines(income.Schedule, now,
+			fmt.Sprintf(model.IncomeTitleStarts, income.Name), fmt.Sprintf(model.IncomeDescriptionStarts, income.Name, amount),
+			fmt.Sprintf(model.IncomeTitleEnds, income.Name), fmt.Sprintf(model.IncomeDescriptionEnds, income.Name, amount))...)
+	}
+	for _, expense := range rec.Expenses {
+		amount := forecast.MonthlyAmount(inSessionCurrency(expense.Amount, expense.ExchangeRate).Float64(), expense.Schedule)
+		timelines = append(timelines, periodTimelines(expense.Schedule, now,
+			fmt.Sprintf(model.ExpenseTitleStarts, expense.Name), fmt.Sprintf(model.ExpenseDescriptionStarts, expense.Name, amount),
+			fmt.Sprintf(model.ExpenseTitleEnds, expense.Name), fmt.Sprintf(model.ExpenseDescriptionEnds, expense.Name, amount))...)
+	}
+
+	for _, goal := range rec.Goals {
+		if goal.ForecastCompletionDate != "" {
+			dt, _ := time.Parse(format, goal.ForecastCompletionDate)
+			timelines = append(timelines, &model.Timeline{
+				Event:       fmt.Sprintf(model.GoalTitleReached, goal.Name),
+				Date:        goal.ForecastCompletionDate,
+				Datetime:    dt,
+				Description: fmt.Sprintf(model.GoalDescriptionReached, goal.TargetAmount.Float64(), goal.Name),
+			})
+		}
+
+		if goal.ForecastStatus == model.GoalStatusBehind {
+			dt := time.Time(goal.TargetDate)
+			timelines = append(timelines, &model.Timeline{
+				Event:       fmt.Sprintf(model.GoalTitleBehind, goal.Name),
+				Date:        dt.Format(format),
+				Datetime:    dt,
+				Description: fmt.Sprintf(model.GoalDescriptionBehind, goal.Name, goal.ForecastSavedAmount.Float64(), goal.TargetAmount.Float64()),
+			})
+		}
+	}
+
+	if rec.ForecastEmergencyBudgetFilledDate != "" {
+		dt, _ := time.Parse(format, rec.ForecastEmergencyBudgetFilledDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastEmergencyBudgetFilled,
+			Date:        rec.ForecastEmergencyBudgetFilledDate,
+			Datetime:    dt,
+			Description: model.SessionDescriptionForecastEmergencyBudgetFilled,
+		})
+	}
+
+	if rec.ForecastRainydayBudgetFilledDate != "" {
+		dt, _ := time.Parse(format, rec.ForecastRainydayBudgetFilledDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastRainydayBudgetFilled,
+			Date:        rec.ForecastRainydayBudgetFilledDate,
+			Datetime:    dt,
+			Description: model.SessionDescriptionForecastRainydayBudgetFilled,
+		})
+	}
+
+	if rec.ForecastStartInvestingDate != "" {
+		dt, _ := time.Parse(format, rec.ForecastStartInvestingDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastStartInvesting,
+			Date:        rec.ForecastStartInvestingDate,
+			Datetime:    dt,
+			Description: model.SessionDescriptionForecastStartInvesting,
+		})
+	}
+
+	if rec.ForecastFinancialFreedomDate != "" {
+		dt, _ := time.Parse(format, rec.ForecastFinancialFreedomDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastFinancialFreedom,
+			Date:        rec.ForecastFinancialFreedomDate,
+			Datetime:    dt,
+			Description: model.SessionDescriptionForecastFinancialFreedom,
+		})
+	}
+
+	if rec.ForecastMillionaireDate != "" {
+		dt, _ := time.Parse(format, rec.ForecastMillionaireDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastMillionaire,
+			Date:        rec.ForecastMillionaireDate,
+			Datetime:    dt,
+			Description: model.SessionDescriptionForecastMillionaire,
+		})
+	}
+
+	if rec.ForecastBankrupt != "" {
+		dt, _ := time.Parse(format, rec.ForecastBankrupt)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastBankrupt,
+			Date:        rec.ForecastBankrupt,
+			Datetime:    dt,
+			Description: model.SessionDescriptionForecastBankrupt,
+		})
+	}
+
+	if rec.Retirement != nil {
+		dt, _ := time.Parse(format, rec.Retirement.RetirementDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastRetirement,
+			Date:        rec.Retirement.RetirementDate,
+			Datetime:    dt,
+			Description: fmt.Sprintf(model.SessionDescriptionForecastRetirement, rec.Retirement.SavingsAtRetirement, rec.Retirement.MonthlyExpenseAtRetirement),
+		})
+	}
+
+	if rec.ForecastMoneyRunsOutDate != "" {
+		dt, _ := time.Parse(format, rec.ForecastMoneyRunsOutDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       model.SessionTitleForecastMoneyRunsOut,
+			Date:        rec.ForecastMoneyRunsOutDate,
+			Datetime:    dt,
+			Description: fmt.Sprintf(model.SessionDescriptionForecastMoneyRunsOut, rec.ForecastMoneyRunsOutAge),
+		})
+	}
+
+	// * ages alongside dates once the birth date is known
+	if rec.BirthDate != nil {
+		for _, timeline := range timelines {
+			timeline.Age = forecast.AgeAt(time.Time(*rec.BirthDate), timeline.Datetime)
+		}
+	}
+
+	sort.Slice(timelines[:], func(i, j int) bool {
+		return timelines[i].Datetime.Before(timelines[j].Datetime)
+	})
+
+	return timelines
+}
+
+// periodTimelines returns the upcoming start and end events of the schedule
+func periodTimelines(s model.Schedule, now time.Time, startTitle, startDescription, endTitle, endDescription string) []*model.Timeline {
+	var timelines []*model.Timeline
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if s.StartDate != nil && !time.Time(*s.StartDate).Before(thisMonth) {
+		dt := time.Time(*s.StartDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       startTitle,
+			Date:        dt.Format(forecast.DateFormat),
+			Datetime:    dt,
+			Description: startDescription,
+		})
+	}
+
+	if s.EndDate != nil && !time.Time(*s.EndDate).Before(thisMonth) {
+		dt := time.Time(*s.EndDate)
+		timelines = append(timelines, &model.Timeline{
+			Event:       endTitle,
+			Date:        dt.Format(forecast.DateFormat),
+			Datetime:    dt,
+			Description: endDescription,
+		})
+	}
+
+	return timelines
+}
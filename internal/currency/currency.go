@@ -0,0 +1,122 @@
+package currency
+
+import (
+	"dullahan/internal/model"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ErrRateNotFound is returned when a currency has no rate effective at the date
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// DateFormat is the effective date format of the rate files
+const DateFormat = "2006-01-02"
+
+// Version holds the rates of the currencies effective from a date, the shape of a rate file entry
+type Version struct {
+	EffectiveDate string             `json:"effective_date"`
+	Rates         map[string]float64 `json:"rates"` // * units of each currency one US dollar buys
+}
+
+// Table converts amounts with the rates effective at a date
+type Table struct {
+	rates map[string][]*model.ExchangeRate // * by currency, oldest first
+}
+
+// NewTable returns the table of the given rates
+func NewTable(rates []*model.ExchangeRate) *Table {
+	t := &Table{rates: make(map[string][]*model.ExchangeRate)}
+	for _, rate := range rates {
+		t.rates[rate.Currency] = append(t.rates[rate.Currency], rate)
+	}
+
+	for _, versions := range t.rates {
+		sort.Slice(versions, func(i, j int) bool {
+			return time.Time(versions[i].EffectiveDate).Before(time.Time(versions[j].EffectiveDate))
+		})
+	}
+
+	return t
+}
+
+// Rate returns how many units of the target currency one unit of the source currency buys at the date
+func (t *Table) Rate(from, to string, at time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, err := t.usdRate(from, at)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := t.usdRate(to, at)
+	if err != nil {
+		return 0, err
+	}
+
+	return toRate / fromRate, nil
+}
+
+// usdRate returns the latest rate of the currency effective at the date
+func (t *Table) usdRate(currency string, at time.Time) (float64, error) {
+	if currency == model.CurrencyUSD {
+		return 1, nil
+	}
+
+	versions := t.rates[currency]
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !time.Time(versions[i].EffectiveDate).After(at) && versions[i].Rate > 0 {
+			return versions[i].Rate, nil
+		}
+	}
+
+	return 0, ErrRateNotFound
+}
+
+// ExchangeRates returns a rate per currency of the version
+func (v Version) ExchangeRates() ([]*model.ExchangeRate, error) {
+	date, err := time.Parse(DateFormat, v.EffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]*model.ExchangeRate, 0, len(v.Rates))
+	for currency, rate := range v.Rates {
+		rates = append(rates, &model.ExchangeRate{
+			Currency:      currency,
+			Rate:          rate,
+			EffectiveDate: datatypes.Date(date),
+		})
+	}
+
+	return rates, nil
+}
+
+// LoadFile reads the versions of a local rate file
+func LoadFile(path string) ([]*model.ExchangeRate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, err
+	}
+
+	var rates []*model.ExchangeRate
+	for _, v := range versions {
+		versionRates, err := v.ExchangeRates()
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, versionRates...)
+	}
+
+	return rates, nil
+}
@@ -2,8 +2,13 @@ package db
 
 import (
 	debtDB "dullahan/internal/db/debt"
+	debtRateDB "dullahan/internal/db/debtrate"
+	exchangeRateDB "dullahan/internal/db/exchangerate"
 	expenseDB "dullahan/internal/db/expense"
+	goalDB "dullahan/internal/db/goal"
+	healthScoreDB "dullahan/internal/db/healthscore"
 	incomeDB "dullahan/internal/db/income"
+	scenarioDB "dullahan/internal/db/scenario"
 	sessionDB "dullahan/internal/db/session"
 
 	"gorm.io/gorm"
@@ -16,6 +21,12 @@ type Service struct {
 	Income  *incomeDB.DB
 	Expense *expenseDB.DB
 	Debt    *debtDB.DB
+	Goal    *goalDB.DB
+
+	DebtRate     *debtRateDB.DB
+	Scenario     *scenarioDB.DB
+	ExchangeRate *exchangeRateDB.DB
+	HealthScore  *healthScoreDB.DB
 }
 
 // New creates db service
@@ -26,5 +37,11 @@ func New(db *gorm.DB) *Service {
 		Income:  incomeDB.NewDB(),
 		Expense: expenseDB.NewDB(),
 		Debt:    debtDB.NewDB(),
+		Goal:    goalDB.NewDB(),
+
+		DebtRate:     debtRateDB.NewDB(),
+		Scenario:     scenarioDB.NewDB(),
+		ExchangeRate: exchangeRateDB.NewDB(),
+		HealthScore:  healthScoreDB.NewDB(),
 	}
 }
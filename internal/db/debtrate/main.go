@@ -0,0 +1,23 @@
+package debtrate
+
+import (
+	"dullahan/internal/model"
+
+	dbutil "github.com/M15t/ghoul/pkg/util/db"
+	"gorm.io/gorm"
+)
+
+// NewDB returns a new debt rate database instance
+func NewDB() *DB {
+	return &DB{dbutil.NewDB(&model.DebtRate{})}
+}
+
+// DB represents the client for debt_rates table
+type DB struct {
+	*dbutil.DB
+}
+
+// ListByDebt get all rates of a debt ordered by effective date
+func (d *DB) ListByDebt(db *gorm.DB, rates *[]*model.DebtRate, debtID int64) error {
+	return db.Where(`debt_id = ?`, debtID).Order(`effective_date ASC`).Find(rates).Error
+}
@@ -0,0 +1,92 @@
+package exchangerate
+
+import (
+	"dullahan/internal/currency"
+	"dullahan/internal/model"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/M15t/ghoul/pkg/server"
+	dbutil "github.com/M15t/ghoul/pkg/util/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNotFound is returned when an amount has no rate to the session currency
+var ErrNotFound = server.NewHTTPError(http.StatusBadRequest, "EXCHANGE_RATE_NOTFOUND", "Exchange rate not found to the session currency")
+
+// NewDB returns a new exchange rate database instance
+func NewDB() *DB {
+	return &DB{dbutil.NewDB(&model.ExchangeRate{})}
+}
+
+// DB represents the client for exchange_rates table
+type DB struct {
+	*dbutil.DB
+}
+
+// ListAll get all rates ordered by effective date then currency
+func (d *DB) ListAll(db *gorm.DB, rates *[]*model.ExchangeRate) error {
+	return db.Order(`effective_date ASC, currency ASC`).Find(rates).Error
+}
+
+// Table get the conversion table of all rates
+func (d *DB) Table(db *gorm.DB) (*currency.Table, error) {
+	rates := []*model.ExchangeRate{}
+	if err := d.ListAll(db, &rates); err != nil {
+		return nil, err
+	}
+
+	return currency.NewTable(rates), nil
+}
+
+// Upsert creates the rates, replacing the rate of a currency already set on the same effective date
+func (d *DB) Upsert(db *gorm.DB, rates []*model.ExchangeRate) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}, {Name: "effective_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(&rates).Error
+}
+
+// SumToSession converts the amounts to the currency of the session at today's rates and sums them up
+func (d *DB) SumToSession(db *gorm.DB, amounts []*model.CurrencyAmount, sessionID int64) (float64, error) {
+	var base string
+	if err := db.Raw(`SELECT currency FROM sessions WHERE id = ?`, sessionID).Scan(&base).Error; err != nil {
+		return 0, err
+	}
+	if base == "" {
+		base = model.CurrencyUSD
+	}
+
+	table, err := d.Table(db)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, amount := range amounts {
+		if amount.Currency == "" {
+			total += amount.Amount
+			continue
+		}
+
+		rate, err := table.Rate(amount.Currency, base, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		total += amount.Amount * rate
+	}
+
+	return total, nil
+}
+
+// Convertible reports whether the currency converts to the currency of the session at today's rates
+func (d *DB) Convertible(db *gorm.DB, code string, sessionID int64) (bool, error) {
+	_, err := d.SumToSession(db, []*model.CurrencyAmount{{Currency: code}}, sessionID)
+	if errors.Is(err, currency.ErrRateNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
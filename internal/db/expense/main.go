@@ -17,12 +17,12 @@ type DB struct {
 	*dbutil.DB
 }
 
-// SumExpenseByType get sum expense by type
-func (d *DB) SumExpenseByType(db *gorm.DB, totaExpense *float64, dataType string, sessionID int64) error {
-	return db.Raw(`SELECT SUM(amount) FROM expenses WHERE session_id = ? AND type = ?`, sessionID, dataType).Scan(totaExpense).Error
+// SumExpenseByType get sum expense by type for each currency
+func (d *DB) SumExpenseByType(db *gorm.DB, totalExpenses *[]*model.CurrencyAmount, dataType string, sessionID int64) error {
+	return db.Raw(`SELECT COALESCE(currency, '') AS currency, SUM(`+model.MonthlyAmountSQL+`) AS amount FROM expenses WHERE session_id = ? AND type = ? AND `+model.ActiveSQL+` GROUP BY COALESCE(currency, '')`, sessionID, dataType).Scan(totalExpenses).Error
 }
 
 // SumTotalExpense get sum expense by session id
 func (d *DB) SumTotalExpense(db *gorm.DB, totaExpense *float64, sessionID int64) error {
-	return db.Raw(`SELECT SUM(amount) FROM expenses WHERE session_id = ?`, sessionID).Scan(totaExpense).Error
+	return db.Raw(`SELECT SUM(`+model.MonthlyAmountSQL+`) FROM expenses WHERE session_id = ? AND `+model.ActiveSQL, sessionID).Scan(totaExpense).Error
 }
@@ -0,0 +1,23 @@
+package goal
+
+import (
+	"dullahan/internal/model"
+
+	dbutil "github.com/M15t/ghoul/pkg/util/db"
+	"gorm.io/gorm"
+)
+
+// NewDB returns a new goal database instance
+func NewDB() *DB {
+	return &DB{dbutil.NewDB(&model.Goal{})}
+}
+
+// DB represents the client for goals table
+type DB struct {
+	*dbutil.DB
+}
+
+// ListBySession get all goals of a session
+func (d *DB) ListBySession(db *gorm.DB, goals *[]*model.Goal, sessionID int64) error {
+	return db.Where(`session_id = ?`, sessionID).Order(`id ASC`).Find(goals).Error
+}
@@ -0,0 +1,34 @@
+package healthscore
+
+import (
+	"dullahan/internal/model"
+
+	dbutil "github.com/M15t/ghoul/pkg/util/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NewDB returns a new health score database instance
+func NewDB() *DB {
+	return &DB{dbutil.NewDB(&model.HealthScore{})}
+}
+
+// DB represents the client for health_scores table
+type DB struct {
+	*dbutil.DB
+}
+
+// Upsert creates the score, replacing the score of the session already recorded on the same day
+func (d *DB) Upsert(db *gorm.DB, score *model.HealthScore) error {
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "session_id"}, {Name: "recorded_on"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"score", "savings_rate_score", "debt_to_income_score", "emergency_fund_score", "high_interest_debt_score", "updated_at",
+		}),
+	}).Create(score).Error
+}
+
+// ListBySession get the scores of a session, oldest first
+func (d *DB) ListBySession(db *gorm.DB, scores *[]*model.HealthScore, sessionID int64) error {
+	return db.Where(`session_id = ?`, sessionID).Order(`recorded_on ASC`).Find(scores).Error
+}
@@ -19,5 +19,5 @@ type DB struct {
 
 // SumTotalIncome get sum total income
 func (d *DB) SumTotalIncome(db *gorm.DB, totalIncome *float64, sessionID int64) error {
-	return db.Raw(`SELECT SUM(amount) FROM incomes WHERE session_id = ?`, sessionID).Scan(totalIncome).Error
+	return db.Raw(`SELECT SUM(`+model.MonthlyAmountSQL+`) FROM incomes WHERE session_id = ? AND `+model.ActiveSQL, sessionID).Scan(totalIncome).Error
 }
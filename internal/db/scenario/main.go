@@ -0,0 +1,28 @@
+package scenario
+
+import (
+	"dullahan/internal/model"
+
+	dbutil "github.com/M15t/ghoul/pkg/util/db"
+	"gorm.io/gorm"
+)
+
+// NewDB returns a new scenario database instance
+func NewDB() *DB {
+	return &DB{dbutil.NewDB(&model.Scenario{})}
+}
+
+// DB represents the client for scenarios table
+type DB struct {
+	*dbutil.DB
+}
+
+// ListBySession get the scenarios of a session, the given ones only when ids are set
+func (d *DB) ListBySession(db *gorm.DB, scenarios *[]*model.Scenario, sessionID int64, ids ...int64) error {
+	db = db.Where(`session_id = ?`, sessionID)
+	if len(ids) > 0 {
+		db = db.Where(`id IN (?)`, ids)
+	}
+
+	return db.Order(`id ASC`).Find(scenarios).Error
+}
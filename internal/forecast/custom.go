@@ -0,0 +1,60 @@
+package forecast
+
+// Const
+const (
+	YearsForCalculation = 5
+	CustomMonth         = 12
+	CustomDay           = 31
+
+	UserBudgetIncreasementRate = 0.00966
+	ExpectedAnnualReturn       = 12.23 // percent, same as the monthly rate above
+	ExpectedVolatility         = 15.00 // percent
+	BankruptCeil               = 200.00
+	GoodFlexibilityRate        = 1.00
+	EmergencyFundRate          = 6.00
+	RainydayFundRate           = 3.00
+	FunFundRate                = 0.2 // 20 percent
+
+	RetirementPlanRate = 10.00 // years
+	RetirementAge      = 65
+	LifeExpectancy     = 90
+	SafeWithdrawalRate = 4.00 // percent of the savings withdrawn each year
+
+	MillionaireRate = 1000000.00 // 1 million dollars
+	MaxSolverYears  = 100        // * how far milestones are searched past the horizon
+
+	HealthSavingsRateTarget  = 20.00 // percent of the income, full points from here
+	HealthDebtToIncomeFloor  = 15.00 // percent of the gross income, full points up to here
+	HealthDebtToIncomeCeil   = 50.00 // percent of the gross income, no points from here
+	HealthHighInterestRate   = 8.00  // annual percent a debt counts as high interest from
+	HealthWeightSavingsRate  = 30.00 // percent of the health score
+	HealthWeightDebtToIncome = 25.00
+	HealthWeightEmergency    = 25.00
+	HealthWeightHighInterest = 20.00
+
+	SensitivityStepPercent  = "PERCENT"
+	SensitivityStepAmount   = "AMOUNT"
+	SensitivityPercent      = 10.00  // percent of the input, the default step
+	SensitivityAmount       = 100.00 // in the session currency, the default amount step
+	SensitivityReturnPoints = 1.00   // percentage points of annual return an amount step moves the return rate by
+
+	DateFormat = "Jan 2006"
+
+	GranularityMonthly   = "MONTHLY"
+	GranularityQuarterly = "QUARTERLY"
+	GranularityYearly    = "YEARLY"
+
+	LineChartGroupAssets  = "Assets"
+	LineChartGroupFunFund = "Fun Fund"
+
+	MilestoneEmergencyFund    = "EMERGENCY_FUND"
+	MilestoneRainydayFund     = "RAINYDAY_FUND"
+	MilestoneStartInvesting   = "START_INVESTING"
+	MilestoneFinancialFreedom = "FINANCIAL_FREEDOM"
+	MilestoneMillionaire      = "MILLIONAIRE"
+	MilestoneDebtFree         = "DEBT_FREE"
+	MilestoneBankrupt         = "BANKRUPT"
+
+	ValuesNominal = "NOMINAL"
+	ValuesReal    = "REAL" // * inflation adjusted, in today's money
+)
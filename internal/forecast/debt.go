@@ -0,0 +1,192 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"math"
+	"sort"
+	"time"
+)
+
+// debtSchedule tracks the amortization of a single debt month by month
+type debtSchedule struct {
+	debt      *model.Debt
+	remaining money.Decimal
+	rate      float64 // * annual interest, in percent
+	term      int     // * months left until the debt is cleared, 0 when open ended
+	maturity  int64   // * month the remaining balance is due at once, -1 without deadline
+
+	start time.Time
+	rates []*model.DebtRate // * pending rate changes, by effective date
+}
+
+func newDebtSchedule(debt *model.Debt, start time.Time) *debtSchedule {
+	d := &debtSchedule{
+		debt:      debt,
+		remaining: debt.RemainingAmount,
+		rate:      debt.AnnualInterest,
+		maturity:  -1,
+		start:     start,
+	}
+
+	if deadline := time.Time(debt.PaymentDeadline); !deadline.IsZero() {
+		d.maturity = int64(math.Max(float64(MonthsBetween(start, deadline)), 0))
+	}
+
+	if debt.IsAmortized() {
+		d.term = amortizationTerm(debt, start)
+	}
+
+	if debt.IsFloating() {
+		d.rates = append(d.rates, debt.Rates...)
+		sort.SliceStable(d.rates, func(i, j int) bool {
+			return time.Time(d.rates[i].EffectiveDate).Before(time.Time(d.rates[j].EffectiveDate))
+		})
+	}
+
+	return d
+}
+
+// reset applies the rate changes effective by the given month, reports whether the rate changed
+func (d *debtSchedule) reset(month int64) bool {
+	var changed bool
+	for len(d.rates) > 0 && int64(MonthsBetween(d.start, time.Time(d.rates[0].EffectiveDate))) <= month {
+		if rate := d.rates[0].Rate(); rate != d.rate {
+			d.rate = rate
+			changed = true
+		}
+		d.rates = d.rates[1:]
+	}
+
+	return changed
+}
+
+// currentRate returns the annual interest of the debt at the start, floating rate changes included
+func currentRate(debt *model.Debt, start time.Time) float64 {
+	d := newDebtSchedule(debt, start)
+	d.reset(0)
+
+	return d.rate
+}
+
+// interest returns the interest accrued on the remaining balance this month
+func (d *debtSchedule) interest() float64 {
+	return d.remaining.Float64() * monthlyRate(d.rate)
+}
+
+// installment returns the payment due this month, never more than what is owed
+func (d *debtSchedule) installment() float64 {
+	payment := d.debt.MonthlyPayment.Float64()
+	if d.term > 0 {
+		payment = annuityPayment(d.remaining.Float64(), d.rate, d.term)
+	}
+
+	if owed := d.remaining.Float64() + d.interest(); payment > owed {
+		payment = owed
+	}
+
+	return payment
+}
+
+// pay accrues this month interest then deducts the installment from the balance
+func (d *debtSchedule) pay() (payment, interest float64) {
+	interest = d.interest()
+	payment = d.installment()
+
+	d.remaining = d.remaining.Add(money.FromFloat(interest - payment)).Round(money.Cents)
+	if d.remaining < 0 {
+		d.remaining = 0
+	}
+	if d.term > 0 {
+		d.term--
+	}
+
+	return payment, interest
+}
+
+// balloon clears the balance left at maturity, returns the amount paid at once
+func (d *debtSchedule) balloon(month int64) float64 {
+	if d.maturity < 0 || month < d.maturity || d.remaining <= 0 {
+		return 0
+	}
+
+	amount := d.remaining.Float64()
+	d.remaining = 0
+
+	return amount
+}
+
+// dueInstallments returns the sum of installments due this month of the given debts
+func dueInstallments(schedules []*debtSchedule) float64 {
+	var total float64
+	for _, sch := range schedules {
+		if sch.remaining > 0 {
+			total += sch.installment()
+		}
+	}
+
+	return total
+}
+
+// amortizationTerm returns the number of months to clear the debt, either until
+// its payment deadline or implied by the current monthly payment
+func amortizationTerm(debt *model.Debt, start time.Time) int {
+	if deadline := time.Time(debt.PaymentDeadline); !deadline.IsZero() && deadline.After(start) {
+		return MonthsBetween(start, deadline) + 1
+	}
+
+	r := monthlyRate(debt.AnnualInterest)
+	remaining, payment := debt.RemainingAmount.Float64(), debt.MonthlyPayment.Float64()
+	switch {
+	case payment <= 0:
+		return 0
+	case r == 0:
+		return int(math.Ceil(remaining / payment))
+	case payment <= remaining*r: // * never pays off
+		return 0
+	}
+
+	return int(math.Ceil(-math.Log(1-r*remaining/payment) / math.Log(1+r)))
+}
+
+// annuityPayment returns the constant installment clearing the balance over the term
+func annuityPayment(balance, annualRate float64, term int) float64 {
+	r := monthlyRate(annualRate)
+	if r == 0 {
+		return balance / float64(term)
+	}
+
+	return balance * r / (1 - math.Pow(1+r, -float64(term)))
+}
+
+func monthlyRate(annualRate float64) float64 {
+	return annualRate / 12.0 / 100
+}
+
+// DeadlineStatus holds what it takes to clear a debt by its payment deadline
+type DeadlineStatus struct {
+	Months          int     // * installments left until the deadline, 0 when overdue
+	RequiredPayment float64 // * monthly payment clearing the debt by the deadline
+}
+
+// CheckDeadline returns the payment clearing the debt by its deadline, false when the
+// debt has no deadline. Whether the debt actually misses it comes from the forecast
+// balloons, amortized debts are sized to the deadline there and never miss it.
+func CheckDeadline(debt *model.Debt, start time.Time) (DeadlineStatus, bool) {
+	deadline := time.Time(debt.PaymentDeadline)
+	if deadline.IsZero() {
+		return DeadlineStatus{}, false
+	}
+
+	status := DeadlineStatus{Months: MonthsBetween(start, deadline) + 1}
+	if status.Months <= 0 {
+		status.Months = 0
+		status.RequiredPayment = debt.RemainingAmount.Float64()
+
+		return status, true
+	}
+
+	status.RequiredPayment = roundFloat(annuityPayment(debt.RemainingAmount.Float64(), debt.AnnualInterest, status.Months))
+
+	return status, true
+}
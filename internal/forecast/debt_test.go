@@ -0,0 +1,65 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// deadlineInput returns a forecast with a single interest free debt due by the end of the year,
+// the balance is too low to pay it off in one go
+func deadlineInput(debtType string, income float64) Input {
+	return Input{
+		CurrentBalance: money.FromFloat(1000),
+		Incomes:        []*model.Income{{Amount: money.FromFloat(income), Type: model.IncomeTypeMonthly, Schedule: model.NewSchedule("", nil)}},
+		Expenses:       []*model.Expense{{Amount: money.FromFloat(1000), Type: model.ExpenseTypeEssential, Schedule: model.NewSchedule("", nil)}},
+		Debts: []*model.Debt{{
+			ID:              1,
+			Type:            debtType,
+			RemainingAmount: money.FromFloat(10000),
+			MonthlyPayment:  money.FromFloat(100),
+			PaymentDeadline: datatypes.Date(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)),
+		}},
+		Params: DefaultParams(),
+		Start:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestFixedPaymentMissesDeadline(t *testing.T) {
+	// * the income covers the expense and the payment only
+	in := deadlineInput(model.DebtTypeFixed, 1100)
+	res := Run(in)
+
+	// * 12 payments of 100 by December, the rest is due at once
+	if got := res.BalloonPaid(1); got != 8800 {
+		t.Fatalf("balloon = %v, want 8800", got)
+	}
+	if len(res.Events.Balloons) != 1 || res.Events.Balloons[0].Date != "Dec 2026" {
+		t.Fatalf("balloons = %+v, want one in Dec 2026", res.Events.Balloons)
+	}
+
+	status, ok := CheckDeadline(in.Debts[0], in.Start)
+	if !ok || status.Months != 12 || status.RequiredPayment != 833.33 {
+		t.Fatalf("status = %+v, %v, want 12 months of 833.33", status, ok)
+	}
+}
+
+func TestAmortizedPaymentMeetsDeadline(t *testing.T) {
+	// * the income covers the expense and the installment sized to the deadline
+	in := deadlineInput(model.DebtTypeFixedAmortized, 1850)
+	res := Run(in)
+
+	if got := res.BalloonPaid(1); got != 0 {
+		t.Fatalf("balloon = %v, want none", got)
+	}
+	paidOff, err := time.Parse(DateFormat, res.Events.DebtPaidOff[1])
+	if err != nil || paidOff.After(time.Time(in.Debts[0].PaymentDeadline)) {
+		t.Fatalf("paid off = %q, want by Dec 2026", res.Events.DebtPaidOff[1])
+	}
+	if got := res.DebtNodes[0].MonthlyPayment; got != 833.33 {
+		t.Fatalf("first installment = %v, want 833.33", got)
+	}
+}
@@ -0,0 +1,661 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"dullahan/internal/tax"
+	"fmt"
+	"time"
+)
+
+// Input holds the plain data a forecast is calculated from
+type Input struct {
+	SessionID      int64
+	CurrentBalance money.Decimal
+
+	Incomes  []*model.Income
+	Expenses []*model.Expense
+	Debts    []*model.Debt
+	Goals    []*model.Goal
+
+	Strategy    string // * debt payoff order, AVALANCHE by default
+	Granularity string // * line chart step, MONTHLY by default
+	Params      Params
+	Start       time.Time
+	BirthDate   time.Time // * retirement is not projected when zero
+
+	InflationAdjusted bool           // * line chart in today's money instead of nominal values
+	Tax               tax.Calculator // * gross incomes are taken as they are when nil
+
+	returns []float64 // * monthly return of each month of a randomized path
+}
+
+// Params holds the assumptions used by the simulation
+type Params struct {
+	Years              int
+	MonthlyReturnRate  float64
+	Volatility         float64 // * annual, in percent
+	InflationRate      float64 // * annual, in percent, applied to expenses
+	EmergencyFundRate  float64
+	RainydayFundRate   float64
+	FunFundRate        float64
+	FunFundCap         float64 // * most set aside for fun each month, no cap when zero
+	FunFundRollover    bool    // * unspent fun fund is kept instead of spent each month
+	RetirementPlanRate float64
+	RetirementAge      int
+	LifeExpectancy     int
+	SafeWithdrawalRate float64 // * annual, in percent, sizes the retirement plan when the birth date is known
+	BankruptCeil       float64
+	GoodFlexibility    float64 // * good flexibility above this many essential expenses of net flow
+	FreedomCoversTotal bool    // * financial freedom covers every expense instead of the essential ones
+	MillionaireRate    float64
+}
+
+// DefaultParams returns the default simulation assumptions
+func DefaultParams() Params {
+	return Params{
+		Years:              YearsForCalculation,
+		MonthlyReturnRate:  UserBudgetIncreasementRate,
+		Volatility:         ExpectedVolatility,
+		EmergencyFundRate:  EmergencyFundRate,
+		RainydayFundRate:   RainydayFundRate,
+		FunFundRate:        FunFundRate,
+		RetirementPlanRate: RetirementPlanRate,
+		RetirementAge:      RetirementAge,
+		LifeExpectancy:     LifeExpectancy,
+		SafeWithdrawalRate: SafeWithdrawalRate,
+		BankruptCeil:       BankruptCeil,
+		GoodFlexibility:    GoodFlexibilityRate,
+		MillionaireRate:    MillionaireRate,
+	}
+}
+
+// Totals holds the monthly aggregates of an input
+type Totals struct {
+	Income              float64 // * net of taxes
+	PassiveIncome       float64 // * part of the income
+	Tax                 float64 // * withheld from the gross incomes
+	TaxRate             float64 // * effective rate of the gross incomes
+	IncomeFlow          float64 // * received in the month, the income when every item is monthly
+	ExpenseFlow         float64 // * paid in the month, the expense when every item is monthly
+	Expense             float64
+	EssentialExpense    float64
+	NonEssentialExpense float64
+	MonthlyPaymentDebt  float64
+}
+
+// Result holds the month by month outcome of a forecast
+type Result struct {
+	Totals     Totals
+	Nodes      []*model.DataNode
+	DebtNodes  []*model.DataDebtNode
+	LineCharts []*model.LineChart
+	Events     Events
+	GoalSaved  map[int64]money.Decimal // * saved by the end of the forecast, keyed by goal ID
+	Retirement *model.RetirementProjection
+
+	lineChartIndex map[string]int // * position of each group and key in line charts
+}
+
+// Events holds the forecast dates of each milestone, empty when never reached
+type Events struct {
+	EmergencyBudgetFilled string
+	RainydayBudgetFilled  string
+	StartInvesting        string
+	FinancialFreedom      string
+	Millionaire           string
+	Bankrupt              string
+	DebtFree              string
+
+	DebtPaidOff map[int64]string // * keyed by debt ID
+	GoalReached map[int64]string // * keyed by goal ID
+	RateResets  []*RateReset
+	Balloons    []*Balloon
+}
+
+// EventMilestones lists the milestones of the forecast events
+var EventMilestones = []string{
+	MilestoneEmergencyFund, MilestoneRainydayFund, MilestoneStartInvesting, MilestoneFinancialFreedom,
+	MilestoneMillionaire, MilestoneDebtFree, MilestoneBankrupt,
+}
+
+// Date returns the forecast date of the milestone, empty when never reached
+func (e Events) Date(milestone string) string {
+	switch milestone {
+	case MilestoneEmergencyFund:
+		return e.EmergencyBudgetFilled
+	case MilestoneRainydayFund:
+		return e.RainydayBudgetFilled
+	case MilestoneStartInvesting:
+		return e.StartInvesting
+	case MilestoneFinancialFreedom:
+		return e.FinancialFreedom
+	case MilestoneMillionaire:
+		return e.Millionaire
+	case MilestoneDebtFree:
+		return e.DebtFree
+	case MilestoneBankrupt:
+		return e.Bankrupt
+	}
+
+	return ""
+}
+
+// Balloon holds the remaining balance of a debt paid at once on its deadline
+type Balloon struct {
+	DebtID int64
+	Date   string
+	Amount float64
+}
+
+// RateReset holds a change of interest rate of a floating debt
+type RateReset struct {
+	DebtID         int64
+	Date           string
+	AnnualInterest float64
+}
+
+// appendLineChart adds a point to the line charts, a point of the same group and
+// key is replaced so that each period keeps its latest value
+func (r *Result) appendLineChart(lc *model.LineChart) {
+	id := lc.Group + "_" + lc.Key
+	if i, ok := r.lineChartIndex[id]; ok {
+		r.LineCharts[i] = lc
+		return
+	}
+
+	r.lineChartIndex[id] = len(r.LineCharts)
+	r.LineCharts = append(r.LineCharts, lc)
+}
+
+// Series returns the assets and the total debt of each period, zero after a bankruptcy
+func (r *Result) Series(keys []string) (assets, debts []float64) {
+	assetByKey := make(map[string]float64, len(keys))
+	debtByKey := make(map[string]float64, len(keys))
+	for _, lc := range r.LineCharts {
+		switch lc.Group {
+		case LineChartGroupAssets:
+			assetByKey[lc.Key] = lc.Asset
+		case LineChartGroupFunFund:
+		default:
+			debtByKey[lc.Key] += lc.Debt
+		}
+	}
+
+	assets = make([]float64, len(keys))
+	debts = make([]float64, len(keys))
+	for i, key := range keys {
+		assets[i] = assetByKey[key]
+		debts[i] = roundFloat(debtByKey[key])
+	}
+
+	return assets, debts
+}
+
+// TotalInterestPaid returns the interest paid on all debts over the forecast
+func (r *Result) TotalInterestPaid() float64 {
+	var total float64
+	for _, node := range r.DebtNodes {
+		total += node.InterestPaid
+	}
+
+	return total
+}
+
+// InterestPaid returns the interest paid on a debt over the forecast
+func (r *Result) InterestPaid(debtID int64) float64 {
+	var total float64
+	for _, node := range r.DebtNodes {
+		if node.DebtID == debtID {
+			total += node.InterestPaid
+		}
+	}
+
+	return total
+}
+
+// BalloonPaid returns the balance of a debt due at once at its payment deadline, 0 when it is cleared in time
+func (r *Result) BalloonPaid(debtID int64) float64 {
+	var total float64
+	for _, balloon := range r.Events.Balloons {
+		if balloon.DebtID == debtID {
+			total += balloon.Amount
+		}
+	}
+
+	return total
+}
+
+// Summarize aggregates the incomes, expenses and debts of the input
+func Summarize(in Input) Totals {
+	var t Totals
+	var gross, grossPassive float64
+	for _, income := range in.Incomes {
+		if !activeIn(&in, income.Schedule, 0) {
+			continue
+		}
+
+		amount := income.Amount.Float64() * income.MonthlyFactor()
+		t.Income += amount
+		if income.Type == model.IncomeTypePassive {
+			t.PassiveIncome += amount
+		}
+		if income.IsGross {
+			gross += amount
+			if income.Type == model.IncomeTypePassive {
+				grossPassive += amount
+			}
+		}
+	}
+	applyTax(&in, &t, gross, grossPassive)
+	for _, expense := range in.Expenses {
+		if !activeIn(&in, expense.Schedule, 0) {
+			continue
+		}
+
+		amount := expense.Amount.Float64() * expense.MonthlyFactor()
+		t.Expense += amount
+		if expense.Type == model.ExpenseTypeEssential {
+			t.EssentialExpense += amount
+		} else {
+			t.NonEssentialExpense += amount
+		}
+	}
+	t.Income, t.PassiveIncome, t.Expense = roundFloat(t.Income), roundFloat(t.PassiveIncome), roundFloat(t.Expense)
+	t.IncomeFlow, t.ExpenseFlow = t.Income, t.Expense
+	for _, debt := range in.Debts {
+		t.MonthlyPaymentDebt += debt.MonthlyPayment.Float64()
+	}
+
+	return t
+}
+
+// Snapshot calculates the node of the current month without simulating ahead
+func Snapshot(in Input) *model.DataNode {
+	t := Summarize(in)
+	funFund := in.Params.funFundAllocation(calculateMonthlyNetFlow(t) - t.MonthlyPaymentDebt)
+
+	node, _ := calculateNode(&in, t, "0",
+		in.CurrentBalance.Round(money.Cents), 0, money.FromFloat(funFund), len(in.Debts) == 0)
+
+	return node
+}
+
+// Run simulates the input month by month over the forecast horizon
+func Run(in Input) *Result {
+	res := simulateMilestones(in)
+	res.Retirement = Retire(in)
+
+	return res
+}
+
+// simulateMilestones runs the simulation then looks past the horizon for the millionaire milestone
+func simulateMilestones(in Input) *Result {
+	res := Simulate(in)
+	if res.Totals.Income > 0 {
+		res.Events.Millionaire = ReachDate(in, res, in.Params.MillionaireRate)
+	}
+
+	return res
+}
+
+// Simulate runs the month by month loop of the forecast, without the milestones
+// that look past the horizon or the retirement plan
+func Simulate(in Input) *Result {
+	var prevNode *model.DataNode
+
+	t := Summarize(in)
+	res := &Result{
+		Totals:         t,
+		Events:         Events{DebtPaidOff: make(map[int64]string), GoalReached: make(map[int64]string)},
+		GoalSaved:      make(map[int64]money.Decimal),
+		lineChartIndex: make(map[string]int),
+	}
+
+	startDate := in.Start
+	endDate := horizonEnd(in)
+	monthlyReturnRate := in.Params.MonthlyReturnRate
+
+	eligiblePaidOff := map[int]bool{0: true}
+	for i := 1; i <= len(in.Debts); i++ {
+		eligiblePaidOff[i] = false
+	}
+
+	debts := OrderDebts(in.Debts, in.Strategy)
+	schedules := make([]*debtSchedule, len(debts))
+	for j, debt := range debts {
+		schedules[j] = newDebtSchedule(debt, startDate)
+	}
+
+	// * assets, funds and goal savings add up exactly, month after month
+	prevAsset := in.CurrentBalance.Round(money.Cents)
+	var funFund money.Decimal
+
+	goals := make([]*goalSchedule, len(in.Goals))
+	for k, goal := range OrderGoals(in.Goals) {
+		goals[k] = newGoalSchedule(goal, startDate)
+	}
+
+	for i, q := range generateMonths(startDate, endDate) {
+		var totalRemainingDebt money.Decimal
+		nodeName := fmt.Sprintf("%d", i)
+		key, date := getPeriod(startDate, q, in.Granularity), getMonthAndYear(startDate, q)
+		tq := totalsAt(&in, t, q)
+
+		// * randomized paths draw the return of each month
+		in.Params.MonthlyReturnRate = monthlyReturnRate
+		if i < len(in.returns) {
+			in.Params.MonthlyReturnRate = in.returns[i]
+		}
+
+		currentAsset := prevAsset.Add(money.FromFloat(tq.IncomeFlow - tq.ExpenseFlow).Round(money.Cents))
+
+		for j, sch := range schedules {
+			var payment, interest float64
+			var isPaidOff bool
+			debt := sch.debt
+			totalRemainingAmount := sch.remaining.Float64()
+
+			// * nothing left to pay since previous month
+			if totalRemainingAmount <= 0 {
+				continue
+			}
+
+			if sch.reset(q) {
+				res.Events.RateResets = append(res.Events.RateResets, &RateReset{
+					DebtID:         debt.ID,
+					Date:           date,
+					AnnualInterest: sch.rate,
+				})
+			}
+
+			// * paid off in one go when the rest of this month installments are still covered
+			if payoff := totalRemainingAmount + sch.interest(); currentAsset.Float64()-payoff > dueInstallments(schedules[j+1:]) {
+				interest = sch.interest()
+				payment = payoff
+				sch.remaining = 0
+			} else {
+				payment, interest = sch.pay()
+			}
+
+			// * whatever is left at the deadline is due at once
+			if balloon := sch.balloon(q); balloon > 0 {
+				payment = payment + balloon
+
+				res.Events.Balloons = append(res.Events.Balloons, &Balloon{
+					DebtID: debt.ID,
+					Date:   date,
+					Amount: roundFloat(balloon),
+				})
+			}
+			currentAsset = currentAsset.Sub(money.FromFloat(payment).Round(money.Cents))
+
+			if sch.remaining <= 0 {
+				isPaidOff = true
+
+				res.Events.DebtPaidOff[debt.ID] = date
+
+				// * update next debt to be eligible paid off
+				eligiblePaidOff[j+1] = true
+			}
+
+			totalRemainingDebt = totalRemainingDebt.Add(sch.remaining)
+
+			// * append debt
+			res.appendLineChart(&model.LineChart{
+				Group: debt.Name,
+				Key:   key,
+				Debt:  deflate(&in, sch.remaining.Float64(), q),
+			})
+
+			res.DebtNodes = append(res.DebtNodes, &model.DataDebtNode{
+				SessionID:         in.SessionID,
+				NodeName:          nodeName,
+				Month:             q,
+				DebtID:            debt.ID,
+				Index:             j,
+				RemainingAmount:   sch.remaining.Float64(),
+				MonthlyPayment:    roundFloat(payment),
+				InterestPaid:      roundFloat(interest),
+				IsEligiblePaidOff: eligiblePaidOff[j],
+				IsPaidOff:         isPaidOff,
+			})
+		}
+
+		// * part of the surplus left after debts is set aside for fun, out of the investable assets
+		allocation := money.FromFloat(in.Params.funFundAllocation(currentAsset.Sub(prevAsset).Float64()))
+		currentAsset = currentAsset.Sub(allocation)
+		if in.Params.FunFundRollover {
+			funFund = funFund.Add(allocation)
+		} else {
+			funFund = allocation
+		}
+
+		res.appendLineChart(&model.LineChart{
+			Group: LineChartGroupFunFund,
+			Key:   key,
+			Asset: deflate(&in, funFund.Float64(), q),
+		})
+
+		// * surplus left after debts and fun funds the goals by priority
+		for _, g := range goals {
+			surplus := currentAsset.Sub(prevAsset)
+			if surplus <= 0 {
+				break
+			}
+			if g.done {
+				continue
+			}
+
+			currentAsset = currentAsset.Sub(g.fund(q, surplus))
+			res.GoalSaved[g.goal.ID] = g.saved
+			if g.done {
+				res.Events.GoalReached[g.goal.ID] = date
+			}
+		}
+
+		// * calculate current node, the portfolio yield is added to the assets
+		curNode, asset := calculateNode(&in, tq, nodeName,
+			currentAsset,                   // * dynamic
+			totalRemainingDebt,             // * dynamic
+			funFund,                        // * dynamic
+			isPaidAllDebt(eligiblePaidOff)) // * dynamic
+
+		if len(debts) > 0 && (prevNode == nil || !prevNode.IsPaidAllDebt) && curNode.IsPaidAllDebt {
+			res.Events.DebtFree = date
+		}
+
+		if t.Income > 0 {
+			if (prevNode == nil || !prevNode.IsAchivedEmergencyFund) && curNode.IsAchivedEmergencyFund {
+				res.Events.EmergencyBudgetFilled = date
+			}
+
+			if (prevNode == nil || !prevNode.IsAchivedRainydayFund) && curNode.IsAchivedRainydayFund {
+				res.Events.RainydayBudgetFilled = date
+			}
+
+			if (prevNode == nil || !prevNode.IsAchivedInvestment) && curNode.IsAchivedEmergencyFund && curNode.IsAchivedRainydayFund {
+				res.Events.StartInvesting = date
+			}
+
+			// * the first month passive incomes and yield cover the expenses
+			if res.Events.FinancialFreedom == "" && curNode.IsAchivedFinancialFreedom {
+				res.Events.FinancialFreedom = date
+			}
+		}
+
+		res.Nodes = append(res.Nodes, curNode)
+		prevNode = curNode
+		prevAsset = asset
+
+		// * append asset
+		if curNode.CurrentAsset > 0 {
+			res.appendLineChart(&model.LineChart{
+				Group: LineChartGroupAssets,
+				Key:   key,
+				Asset: deflate(&in, curNode.CurrentAsset, q),
+			})
+		} else {
+			res.appendLineChart(&model.LineChart{
+				Group: LineChartGroupAssets,
+				Key:   key,
+				Asset: 0,
+			})
+			res.Events.Bankrupt = date
+			break
+		}
+	}
+
+	return res
+}
+
+// PeriodKeys returns the line chart key of each period of the forecast horizon
+func PeriodKeys(in Input) []string {
+	var keys []string
+	for _, q := range generateMonths(in.Start, horizonEnd(in)) {
+		if key := getPeriod(in.Start, q, in.Granularity); len(keys) == 0 || keys[len(keys)-1] != key {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// horizonEnd returns the last day of the forecast horizon
+func horizonEnd(in Input) time.Time {
+	return time.Date(in.Start.Year()+in.Params.Years, CustomMonth, CustomDay, 0, 0, 0, 0, time.UTC)
+}
+
+// calculateNode returns the node of the month and the assets once the portfolio yield is added
+func calculateNode(in *Input, t Totals, nodeName string, currentAsset, totalRemainingDebt, actualFunFund money.Decimal, isPaidAllDebt bool) (*model.DataNode, money.Decimal) {
+	var expectedEmergencyFund, expectedRainydayFund, expectedFunFund, actualEmergencyFund, actualRainydayFund, retirementPlan float64
+	var isAchivedInvestment, isAchivedEmergencyFund, isAchivedRainydayFund, isAchivedRetirementPlan bool
+	var portfolioYield, effectiveTaxRate float64
+
+	p := in.Params
+	monthlyNetFlow := calculateMonthlyNetFlow(t)
+
+	grossIncome := roundFloat(t.Income + t.Tax)
+	if grossIncome > 0 {
+		effectiveTaxRate = roundFloat(t.Tax / grossIncome * 100)
+	}
+
+	totalEssentialExpense := t.EssentialExpense
+
+	expectedEmergencyFund = roundFloat(totalEssentialExpense * p.EmergencyFundRate)
+	expectedRainydayFund = roundFloat(totalEssentialExpense * p.RainydayFundRate)
+	expectedFunFund = p.funFundAllocation(monthlyNetFlow)
+
+	retirementPlan = roundFloat(totalEssentialExpense * 12 * p.RetirementPlanRate)
+	if !in.BirthDate.IsZero() && p.SafeWithdrawalRate > 0 {
+		// * enough to withdraw every expense at the safe rate
+		retirementPlan = roundFloat(t.Expense * 12 / (p.SafeWithdrawalRate / 100))
+	}
+
+	// * only achived when emergency fund and rainy day fund is achived and no debt
+	if isPaidAllDebt {
+		netAsset := currentAsset.Sub(totalRemainingDebt).Float64()
+
+		actualEmergencyFund = roundFloat(netAsset)
+		if actualEmergencyFund <= 0 {
+			actualEmergencyFund = 0
+		}
+		actualRainydayFund = roundFloat(netAsset - expectedEmergencyFund)
+		if actualRainydayFund <= 0 {
+			actualRainydayFund = 0
+		}
+
+		isAchivedEmergencyFund = netAsset >= expectedEmergencyFund
+		if isAchivedEmergencyFund {
+			actualEmergencyFund = expectedEmergencyFund
+		}
+
+		isAchivedRainydayFund = netAsset >= (expectedEmergencyFund + expectedRainydayFund)
+		if isAchivedRainydayFund {
+			actualRainydayFund = expectedRainydayFund
+		}
+
+		if isAchivedEmergencyFund && isAchivedRainydayFund {
+			isAchivedInvestment = true
+
+			// * calculate R
+			r := currentAsset.Float64() - (actualEmergencyFund + actualRainydayFund)
+
+			if r >= 0 {
+				portfolioYield = r * p.MonthlyReturnRate
+				currentAsset = currentAsset.Add(money.FromFloat(portfolioYield).Round(money.Cents))
+			}
+
+			isAchivedRetirementPlan = netAsset >= retirementPlan && retirementPlan > 0
+		}
+	}
+
+	// * financially free once passive incomes and portfolio yield cover the expenses
+	freedomCover := totalEssentialExpense
+	if p.FreedomCoversTotal {
+		freedomCover = t.Expense
+	}
+	isAchivedFinancialFreedom := freedomCover > 0 && t.PassiveIncome+portfolioYield >= freedomCover
+
+	var status string
+	goodFlexibilityFloor := totalEssentialExpense * p.GoodFlexibility
+	switch {
+	case monthlyNetFlow < 0:
+		status = model.SessionStatusBD
+	case 0 <= monthlyNetFlow && monthlyNetFlow < p.BankruptCeil:
+		status = model.SessionStatusPC2PC
+	case p.BankruptCeil <= monthlyNetFlow && monthlyNetFlow <= goodFlexibilityFloor:
+		status = model.SessionStatusLFF
+	case goodFlexibilityFloor < monthlyNetFlow:
+		status = model.SessionStatusGFF
+	default:
+		status = model.SessionStatusDefault
+	}
+
+	return &model.DataNode{
+		SessionID:                 in.SessionID,
+		NodeName:                  nodeName,
+		CurrentAsset:              currentAsset.Round(money.Cents).Float64(),
+		TotalAllIncome:            t.Income,
+		TotalPassiveIncome:        roundFloat(t.PassiveIncome),
+		TotalGrossIncome:          grossIncome,
+		TotalIncomeTax:            roundFloat(t.Tax),
+		EffectiveTaxRate:          effectiveTaxRate,
+		TotalAllExpense:           t.Expense,
+		TotalEssentialExpense:     roundFloat(t.EssentialExpense),
+		TotalNonEssentialExpense:  roundFloat(t.NonEssentialExpense),
+		TotalMonthlyPaymentDebt:   roundFloat(t.MonthlyPaymentDebt),
+		TotalRemainingDebt:        totalRemainingDebt.Round(money.Cents).Float64(),
+		MonthlyNetFlow:            monthlyNetFlow,
+		Status:                    status,
+		Descrtiption:              model.SessionStatusDescriptions[status],
+		ExpectedEmergencyFund:     expectedEmergencyFund,
+		ExpectedRainydayFund:      expectedRainydayFund,
+		ExpectFunFund:             expectedFunFund,
+		ActualEmergencyFund:       actualEmergencyFund,
+		ActualRainydayFund:        actualRainydayFund,
+		ActualFunFund:             actualFunFund.Round(money.Cents).Float64(),
+		RetirementPlan:            retirementPlan,
+		IsAchivedEmergencyFund:    isAchivedEmergencyFund,
+		IsAchivedRainydayFund:     isAchivedRainydayFund,
+		IsAchivedInvestment:       isAchivedInvestment,
+		IsAchivedRetirementPlan:   isAchivedRetirementPlan,
+		IsAchivedFinancialFreedom: isAchivedFinancialFreedom,
+		IsPaidAllDebt:             isPaidAllDebt,
+	}, currentAsset
+}
+
+func isPaidAllDebt(m map[int]bool) bool {
+	for _, v := range m {
+		if !v {
+			return false
+		}
+	}
+	return true
+}
+
+func calculateMonthlyNetFlow(t Totals) float64 {
+	totalExpense := roundFloat(t.EssentialExpense + t.NonEssentialExpense)
+
+	return t.Income - totalExpense
+}
@@ -0,0 +1,121 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"math"
+	"testing"
+	"time"
+)
+
+// simulateInput returns a two years forecast of a monthly income and essential expense,
+// without returns nor fun fund so that every month can be worked out by hand
+func simulateInput(balance, income, expense float64) Input {
+	params := DefaultParams()
+	params.Years = 1
+	params.MonthlyReturnRate = 0
+	params.FunFundRate = 0
+
+	return Input{
+		CurrentBalance: money.FromFloat(balance),
+		Incomes:        []*model.Income{{Amount: money.FromFloat(income), Type: model.IncomeTypeMonthly, Schedule: model.NewSchedule("", nil)}},
+		Expenses:       []*model.Expense{{Amount: money.FromFloat(expense), Type: model.ExpenseTypeEssential, Schedule: model.NewSchedule("", nil)}},
+		Params:         params,
+		Start:          time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSimulateAccumulatesNetFlow(t *testing.T) {
+	res := Simulate(simulateInput(1000, 3000, 1000))
+
+	if len(res.Nodes) != 24 {
+		t.Fatalf("nodes = %d, want 24", len(res.Nodes))
+	}
+	for i, node := range res.Nodes {
+		// * 2000 saved each month on top of the balance
+		if want := 1000 + 2000*float64(i+1); node.CurrentAsset != want {
+			t.Errorf("month %d asset = %v, want %v", i, node.CurrentAsset, want)
+		}
+	}
+	if res.Events.Bankrupt != "" {
+		t.Errorf("bankrupt = %q, want never", res.Events.Bankrupt)
+	}
+}
+
+func TestSimulatePaysOffDebt(t *testing.T) {
+	in := simulateInput(100, 2100, 1000)
+	in.Debts = []*model.Debt{{
+		ID:              1,
+		Type:            model.DebtTypeFixed,
+		RemainingAmount: money.FromFloat(10000),
+		MonthlyPayment:  money.FromFloat(1000),
+		AnnualInterest:  12,
+	}}
+	res := Simulate(in)
+
+	// * 1% interest a month, 1000 paid while the assets grow by the 100 left
+	wantRemaining := []float64{9100, 8191, 7272.91, 6345.64, 5409.1, 4463.19, 3507.82, 2542.9, 1568.33, 0}
+	wantInterest := []float64{100, 91, 81.91, 72.73, 63.46, 54.09, 44.63, 35.08, 25.43, 15.68}
+	if len(res.DebtNodes) != len(wantRemaining) {
+		t.Fatalf("debt nodes = %d, want %d", len(res.DebtNodes), len(wantRemaining))
+	}
+	for i, node := range res.DebtNodes {
+		if math.Abs(node.RemainingAmount-wantRemaining[i]) > 0.001 {
+			t.Errorf("month %d remaining = %v, want %v", i, node.RemainingAmount, wantRemaining[i])
+		}
+		if node.InterestPaid != wantInterest[i] {
+			t.Errorf("month %d interest = %v, want %v", i, node.InterestPaid, wantInterest[i])
+		}
+	}
+
+	// * in October the assets cover the 1584.01 left, paid off in one go
+	last := res.DebtNodes[len(res.DebtNodes)-1]
+	if !last.IsPaidOff || last.MonthlyPayment != 1584.01 {
+		t.Errorf("last payment = %v paid off %v, want 1584.01 paid off", last.MonthlyPayment, last.IsPaidOff)
+	}
+	if got := res.Events.DebtPaidOff[1]; got != "Oct 2026" {
+		t.Errorf("paid off = %q, want Oct 2026", got)
+	}
+	if res.Events.DebtFree != "Oct 2026" {
+		t.Errorf("debt free = %q, want Oct 2026", res.Events.DebtFree)
+	}
+	if got := roundFloat(res.TotalInterestPaid()); got != 584.01 {
+		t.Errorf("interest paid = %v, want 584.01", got)
+	}
+
+	wantAssets := map[int]float64{0: 200, 1: 300, 8: 1000, 9: 515.99, 10: 1615.99}
+	for i, want := range wantAssets {
+		if got := res.Nodes[i].CurrentAsset; got != want {
+			t.Errorf("month %d asset = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestSimulateCompoundsReturns(t *testing.T) {
+	in := simulateInput(100000, 1000, 1000)
+	in.Params.MonthlyReturnRate = 0.01
+	res := Simulate(in)
+
+	// * 6000 of emergency fund and 3000 of rainy day fund are kept out of the 1% return
+	want := []float64{100910, 101829.1, 102757.39}
+	for i, w := range want {
+		if got := res.Nodes[i].CurrentAsset; got != w {
+			t.Errorf("month %d asset = %v, want %v", i, got, w)
+		}
+	}
+
+	// * the invested part compounds to 91000 * 1.01^24 give or take the cents rounded each month
+	if got, w := res.Nodes[23].CurrentAsset, 9000+91000*math.Pow(1.01, 24); math.Abs(got-w) > 0.1 {
+		t.Errorf("last asset = %v, want about %v", got, w)
+	}
+}
+
+func TestSimulateAddsUpCents(t *testing.T) {
+	// * ten cents saved a month, a float sum would drift off the cents
+	res := Simulate(simulateInput(0.1, 1000.1, 1000))
+	for i, node := range res.Nodes {
+		if want := money.Decimal(1000 * (i + 2)).Float64(); node.CurrentAsset != want {
+			t.Errorf("month %d asset = %v, want %v", i, node.CurrentAsset, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"sort"
+	"time"
+)
+
+// goalSchedule tracks the savings of a single goal month by month
+type goalSchedule struct {
+	goal   *model.Goal
+	saved  money.Decimal
+	target int64 // * month of the target date
+	done   bool
+}
+
+func newGoalSchedule(goal *model.Goal, start time.Time) *goalSchedule {
+	return &goalSchedule{
+		goal:   goal,
+		target: int64(MonthsBetween(start, time.Time(goal.TargetDate))),
+	}
+}
+
+// contribution returns the saving the goal needs this month to be funded by its target date,
+// everything left once the date has passed
+func (g *goalSchedule) contribution(month int64) money.Decimal {
+	need := g.goal.TargetAmount.Sub(g.saved)
+	if need <= 0 {
+		return 0
+	}
+
+	if left := g.target - month + 1; left > 1 {
+		return need.Div(left)
+	}
+
+	return need
+}
+
+// fund saves up to the given amount towards the goal, returns the amount saved
+func (g *goalSchedule) fund(month int64, surplus money.Decimal) money.Decimal {
+	amount := g.contribution(month)
+	if amount > surplus {
+		amount = surplus
+	}
+	amount = amount.Round(money.Cents)
+	g.saved = g.saved.Add(amount)
+
+	if g.saved >= g.goal.TargetAmount.Round(money.Cents) {
+		g.done = true
+	}
+
+	return amount
+}
+
+// hasOpenGoals reports whether a goal still takes contributions at the end of the forecast
+func hasOpenGoals(in *Input, res *Result) bool {
+	for _, goal := range in.Goals {
+		if _, ok := res.Events.GoalReached[goal.ID]; !ok && goal.TargetAmount > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrderGoals returns a copy of the goals in funding order, prioritized goals first
+// then the closest target date
+func OrderGoals(goals []*model.Goal) []*model.Goal {
+	ordered := append([]*model.Goal{}, goals...)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		switch {
+		case a.Priority > 0 && b.Priority > 0 && a.Priority != b.Priority:
+			return a.Priority < b.Priority
+		case a.Priority > 0 && b.Priority <= 0:
+			return true
+		case a.Priority <= 0 && b.Priority > 0:
+			return false
+		}
+		return time.Time(a.TargetDate).Before(time.Time(b.TargetDate))
+	})
+
+	return ordered
+}
+
+// GoalStatus returns whether the goal is funded by its target date
+func GoalStatus(goal *model.Goal, completionDate string) string {
+	completion, err := time.Parse(DateFormat, completionDate)
+	if err != nil || MonthsBetween(time.Time(goal.TargetDate), completion) > 0 {
+		return model.GoalStatusBehind
+	}
+
+	return model.GoalStatusOnTrack
+}
@@ -0,0 +1,35 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+func TestGoalScheduleFundsExactly(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := newGoalSchedule(&model.Goal{
+		TargetAmount: money.FromFloat(1000),
+		TargetDate:   datatypes.Date(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)),
+	}, start)
+
+	// * a third of what is left each month, the last month takes the rest
+	want := []string{"333.33", "333.34", "333.33"}
+	for month, w := range want {
+		if got := g.fund(int64(month), money.FromFloat(5000)); got.String() != w {
+			t.Errorf("month %d funded %v, want %s", month, got, w)
+		}
+	}
+	if g.saved != money.FromFloat(1000) || !g.done {
+		t.Errorf("saved %v done %v, want 1000 done", g.saved, g.done)
+	}
+
+	// * never more than the surplus
+	g = newGoalSchedule(&model.Goal{TargetAmount: money.FromFloat(1000)}, start)
+	if got := g.fund(0, money.FromFloat(250.5)); got != money.FromFloat(250.5) || g.done {
+		t.Errorf("funded %v done %v, want 250.5 not done", got, g.done)
+	}
+}
@@ -0,0 +1,130 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"math"
+	"time"
+)
+
+// totalsAt returns the monthly aggregates of the given month, expenses grow with
+// inflation while each income is raised on its anniversary month. Only the items active
+// that month count, the flows are what is actually received and paid.
+func totalsAt(in *Input, t Totals, month int64) Totals {
+	if month > 0 && hasGrowth(in) {
+		t = grownTotals(in, t, month)
+	}
+
+	t.IncomeFlow, t.ExpenseFlow = t.Income, t.Expense
+	if hasSchedules(in) {
+		t.IncomeFlow, t.ExpenseFlow = flowsAt(in, t, month)
+	}
+
+	return t
+}
+
+// grownTotals returns the monthly aggregates after inflation and raises
+func grownTotals(in *Input, t Totals, month int64) Totals {
+	var essential, nonEssential float64
+	for _, exp := range in.Expenses {
+		if !activeIn(in, exp.Schedule, month) {
+			continue
+		}
+
+		if exp.Type == model.ExpenseTypeEssential {
+			essential += exp.Amount.Float64() * exp.MonthlyFactor()
+		} else {
+			nonEssential += exp.Amount.Float64() * exp.MonthlyFactor()
+		}
+	}
+
+	inflation := inflationFactor(in.Params.InflationRate, month)
+	t.Expense = roundFloat((essential + nonEssential) * inflation)
+	t.EssentialExpense = roundFloat(essential * inflation)
+	t.NonEssentialExpense = roundFloat(nonEssential * inflation)
+
+	var income, passive, gross, grossPassive float64
+	for _, inc := range in.Incomes {
+		if !activeIn(in, inc.Schedule, month) {
+			continue
+		}
+
+		raises := anniversaries(in.Start, raiseMonth(inc), month)
+		amount := inc.Amount.Float64() * inc.MonthlyFactor() * math.Pow(1+inc.AnnualRaiseRate/100, float64(raises))
+
+		income += amount
+		if inc.Type == model.IncomeTypePassive {
+			passive += amount
+		}
+		if inc.IsGross {
+			gross += amount
+			if inc.Type == model.IncomeTypePassive {
+				grossPassive += amount
+			}
+		}
+	}
+	t.Income = roundFloat(income)
+	t.PassiveIncome = roundFloat(passive)
+	t.Tax, t.TaxRate = 0, 0
+	applyTax(in, &t, gross, grossPassive)
+
+	return t
+}
+
+// hasGrowth reports whether the monthly aggregates change over the horizon
+func hasGrowth(in *Input) bool {
+	if in.Params.InflationRate != 0 {
+		return true
+	}
+
+	for _, income := range in.Incomes {
+		if income.AnnualRaiseRate != 0 || income.IsBounded() {
+			return true
+		}
+	}
+	for _, expense := range in.Expenses {
+		if expense.IsBounded() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inflationFactor returns how much prices grew after the given number of months
+func inflationFactor(annualRate float64, month int64) float64 {
+	return math.Pow(1+annualRate/100, float64(month)/12)
+}
+
+// deflate returns the amount in today's money when inflation adjusted values are requested
+func deflate(in *Input, amount float64, month int64) float64 {
+	if !in.InflationAdjusted || in.Params.InflationRate == 0 {
+		return amount
+	}
+
+	return roundFloat(amount / inflationFactor(in.Params.InflationRate, month))
+}
+
+// raiseMonth returns the anniversary month of the income, the month it was added by default
+func raiseMonth(income *model.Income) time.Month {
+	if income.RaiseMonth >= 1 && income.RaiseMonth <= 12 {
+		return time.Month(income.RaiseMonth)
+	}
+	if !income.CreatedAt.IsZero() {
+		return income.CreatedAt.Month()
+	}
+
+	return time.January
+}
+
+// anniversaries counts the anniversary months passed after the start, up to the given month
+func anniversaries(start time.Time, anniversary time.Month, month int64) int64 {
+	first := int64((int(anniversary) - int(start.Month()) + 12) % 12)
+	if first == 0 {
+		first = 12
+	}
+	if month < first {
+		return 0
+	}
+
+	return 1 + (month-first)/12
+}
@@ -0,0 +1,136 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Health scores the financial health of the input from 0 to 100, as the weighted
+// average of its savings rate, debt to income, emergency fund coverage and high
+// interest debt sub-scores
+func Health(in Input) *model.HealthScore {
+	t := Summarize(in)
+
+	components := []*model.HealthComponent{
+		savingsRateHealth(t),
+		debtToIncomeHealth(t),
+		emergencyFundHealth(&in, t),
+		highInterestDebtHealth(&in),
+	}
+
+	var score float64
+	for _, c := range components {
+		score += float64(c.Score) * c.Weight / 100
+		c.Impact = roundFloat(float64(100-c.Score) * c.Weight / 100)
+	}
+
+	h := &model.HealthScore{
+		Score:                 int(math.Round(score)),
+		SavingsRateScore:      components[0].Score,
+		DebtToIncomeScore:     components[1].Score,
+		EmergencyFundScore:    components[2].Score,
+		HighInterestDebtScore: components[3].Score,
+	}
+
+	// * the improvement worth the most points first
+	sort.SliceStable(components, func(i, j int) bool { return components[i].Impact > components[j].Impact })
+	h.Components = components
+
+	return h
+}
+
+// savingsRateHealth scores the part of the income left after expenses and debt payments
+func savingsRateHealth(t Totals) *model.HealthComponent {
+	c := &model.HealthComponent{
+		Name:        model.HealthComponentSavingsRate,
+		Weight:      HealthWeightSavingsRate,
+		Explanation: model.HealthExplanationNoIncome,
+	}
+	if t.Income <= 0 {
+		return c
+	}
+
+	c.Value = roundFloat((t.Income - t.Expense - t.MonthlyPaymentDebt) / t.Income * 100)
+	c.Score = healthScore(c.Value / HealthSavingsRateTarget)
+	c.Explanation = fmt.Sprintf(model.HealthExplanationSavingsRate, c.Value, HealthSavingsRateTarget)
+
+	return c
+}
+
+// debtToIncomeHealth scores the part of the gross income going to debt payments
+func debtToIncomeHealth(t Totals) *model.HealthComponent {
+	c := &model.HealthComponent{
+		Name:        model.HealthComponentDebtToIncome,
+		Score:       100,
+		Weight:      HealthWeightDebtToIncome,
+		Explanation: model.HealthExplanationNoDebtPayment,
+	}
+	if t.MonthlyPaymentDebt <= 0 {
+		return c
+	}
+
+	c.Value, c.Score = 100, 0
+	if gross := t.Income + t.Tax; gross > 0 {
+		c.Value = roundFloat(t.MonthlyPaymentDebt / gross * 100)
+		c.Score = healthScore((HealthDebtToIncomeCeil - c.Value) / (HealthDebtToIncomeCeil - HealthDebtToIncomeFloor))
+	}
+	c.Explanation = fmt.Sprintf(model.HealthExplanationDebtToIncome, c.Value, HealthDebtToIncomeFloor, HealthDebtToIncomeCeil)
+
+	return c
+}
+
+// emergencyFundHealth scores how many months of essential expenses the balance covers
+func emergencyFundHealth(in *Input, t Totals) *model.HealthComponent {
+	c := &model.HealthComponent{
+		Name:        model.HealthComponentEmergencyFund,
+		Score:       100,
+		Weight:      HealthWeightEmergency,
+		Explanation: model.HealthExplanationNoEssential,
+	}
+	if t.EssentialExpense <= 0 {
+		return c
+	}
+
+	c.Value = roundFloat(math.Max(in.CurrentBalance.Float64(), 0) / t.EssentialExpense)
+	if target := in.Params.EmergencyFundRate; target > 0 {
+		c.Score = healthScore(c.Value / target)
+	}
+	c.Explanation = fmt.Sprintf(model.HealthExplanationEmergencyFund, c.Value, in.Params.EmergencyFundRate)
+
+	return c
+}
+
+// highInterestDebtHealth scores the part of the remaining debt charging a high interest
+func highInterestDebtHealth(in *Input) *model.HealthComponent {
+	c := &model.HealthComponent{
+		Name:        model.HealthComponentHighInterestDebt,
+		Score:       100,
+		Weight:      HealthWeightHighInterest,
+		Explanation: model.HealthExplanationNoDebt,
+	}
+
+	var total, high float64
+	for _, debt := range in.Debts {
+		remaining := debt.RemainingAmount.Float64()
+		total += remaining
+		if currentRate(debt, in.Start) >= HealthHighInterestRate {
+			high += remaining
+		}
+	}
+	if total <= 0 {
+		return c
+	}
+
+	c.Value = roundFloat(high / total * 100)
+	c.Score = healthScore(1 - c.Value/100)
+	c.Explanation = fmt.Sprintf(model.HealthExplanationHighInterestDebt, c.Value, HealthHighInterestRate)
+
+	return c
+}
+
+// healthScore returns the points of a ratio of the full points, between 0 and 100
+func healthScore(ratio float64) int {
+	return int(math.Round(math.Min(math.Max(ratio, 0), 1) * 100))
+}
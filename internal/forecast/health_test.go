@@ -0,0 +1,142 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+func TestSavingsRateHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals Totals
+		value  float64
+		score  int
+	}{
+		{"no income", Totals{Expense: 1000}, 0, 0},
+		{"at the target", Totals{Income: 1000, Expense: 800}, 20, 100},
+		{"above the target", Totals{Income: 1000, Expense: 500}, 50, 100},
+		{"half the target", Totals{Income: 1000, Expense: 800, MonthlyPaymentDebt: 100}, 10, 50},
+		{"nothing saved", Totals{Income: 1000, Expense: 1000}, 0, 0},
+		{"spending more", Totals{Income: 1000, Expense: 1200}, -20, 0},
+	}
+
+	for _, tt := range tests {
+		c := savingsRateHealth(tt.totals)
+		if c.Value != tt.value || c.Score != tt.score {
+			t.Errorf("%s: value, score = %v, %d, want %v, %d", tt.name, c.Value, c.Score, tt.value, tt.score)
+		}
+	}
+}
+
+func TestDebtToIncomeHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals Totals
+		value  float64
+		score  int
+	}{
+		{"no debt payment", Totals{Income: 1000}, 0, 100},
+		{"at the floor", Totals{Income: 1000, MonthlyPaymentDebt: 150}, 15, 100},
+		{"at the floor of the gross income", Totals{Income: 800, Tax: 200, MonthlyPaymentDebt: 150}, 15, 100},
+		{"halfway", Totals{Income: 1000, MonthlyPaymentDebt: 325}, 32.5, 50},
+		{"at the ceiling", Totals{Income: 1000, MonthlyPaymentDebt: 500}, 50, 0},
+		{"no income", Totals{MonthlyPaymentDebt: 100}, 100, 0},
+	}
+
+	for _, tt := range tests {
+		c := debtToIncomeHealth(tt.totals)
+		if c.Value != tt.value || c.Score != tt.score {
+			t.Errorf("%s: value, score = %v, %d, want %v, %d", tt.name, c.Value, c.Score, tt.value, tt.score)
+		}
+	}
+}
+
+func TestEmergencyFundHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		balance   float64
+		essential float64
+		value     float64
+		score     int
+	}{
+		{"no essential expense", 0, 0, 0, 100},
+		{"six months covered", 6000, 1000, 6, 100},
+		{"three months covered", 3000, 1000, 3, 50},
+		{"nothing covered", 0, 1000, 0, 0},
+		{"overdrawn", -1000, 1000, 0, 0},
+	}
+
+	for _, tt := range tests {
+		in := Input{CurrentBalance: money.FromFloat(tt.balance), Params: DefaultParams()}
+		c := emergencyFundHealth(&in, Totals{EssentialExpense: tt.essential})
+		if c.Value != tt.value || c.Score != tt.score {
+			t.Errorf("%s: value, score = %v, %d, want %v, %d", tt.name, c.Value, c.Score, tt.value, tt.score)
+		}
+	}
+}
+
+func TestHighInterestDebtHealth(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	debt := func(debtType string, remaining, interest float64, rates ...*model.DebtRate) *model.Debt {
+		return &model.Debt{Type: debtType, RemainingAmount: money.FromFloat(remaining), AnnualInterest: interest, Rates: rates}
+	}
+	rate := func(interest float64, effective time.Time) *model.DebtRate {
+		return &model.DebtRate{Type: model.DebtRateTypeRate, AnnualInterest: interest, EffectiveDate: datatypes.Date(effective)}
+	}
+
+	tests := []struct {
+		name  string
+		debts []*model.Debt
+		value float64
+		score int
+	}{
+		{"no debt", nil, 0, 100},
+		{"below the rate", []*model.Debt{debt(model.DebtTypeFixed, 1000, 7.99)}, 0, 100},
+		{"at the rate", []*model.Debt{debt(model.DebtTypeFixed, 1000, 8)}, 100, 0},
+		{"half of the debt", []*model.Debt{debt(model.DebtTypeFixed, 1000, 3), debt(model.DebtTypeFixed, 1000, 12)}, 50, 50},
+		{"paid off", []*model.Debt{debt(model.DebtTypeFixed, 0, 12)}, 0, 100},
+		{
+			"floating rate raised this month",
+			[]*model.Debt{debt(model.DebtTypeFloat, 1000, 5, rate(9, start.AddDate(0, -6, 0)), rate(10, start))}, 100, 0,
+		},
+		{
+			"floating rate lowered last year",
+			[]*model.Debt{debt(model.DebtTypeFloat, 1000, 12, rate(6, start.AddDate(-1, 0, 0)))}, 0, 100,
+		},
+		{
+			"floating rate raised next month",
+			[]*model.Debt{debt(model.DebtTypeFloat, 1000, 5, rate(9, start.AddDate(0, 1, 0)))}, 0, 100,
+		},
+	}
+
+	for _, tt := range tests {
+		in := Input{Debts: tt.debts, Start: start}
+		c := highInterestDebtHealth(&in)
+		if c.Value != tt.value || c.Score != tt.score {
+			t.Errorf("%s: value, score = %v, %d, want %v, %d", tt.name, c.Value, c.Score, tt.value, tt.score)
+		}
+	}
+}
+
+func TestHealthWeighsComponents(t *testing.T) {
+	// * saves 10% of the income for half the points, six months of essential expenses and no debt
+	in := simulateInput(5400, 1000, 900)
+
+	h := Health(in)
+	if h.SavingsRateScore != 50 || h.DebtToIncomeScore != 100 || h.EmergencyFundScore != 100 || h.HighInterestDebtScore != 100 {
+		t.Fatalf("scores = %d, %d, %d, %d, want 50, 100, 100, 100",
+			h.SavingsRateScore, h.DebtToIncomeScore, h.EmergencyFundScore, h.HighInterestDebtScore)
+	}
+
+	// * 30% weight at half the points loses 15 of them
+	if h.Score != 85 {
+		t.Errorf("score = %d, want 85", h.Score)
+	}
+	if first := h.Components[0]; first.Name != model.HealthComponentSavingsRate || first.Impact != 15 {
+		t.Errorf("first component = %s losing %v, want %s losing 15", first.Name, first.Impact, model.HealthComponentSavingsRate)
+	}
+}
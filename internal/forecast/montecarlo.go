@@ -0,0 +1,143 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Milestones lists the milestones measured over randomized paths
+var Milestones = []string{MilestoneEmergencyFund, MilestoneFinancialFreedom, MilestoneMillionaire}
+
+// MonteCarlo holds the outcome of randomized forecast paths
+type MonteCarlo struct {
+	Paths      int
+	Seed       int64
+	Bands      []*model.AssetBand
+	Milestones []*model.MilestoneProbability
+}
+
+// RunMonteCarlo simulates the input over randomized paths, the return of each month is
+// drawn from the expected return and volatility. The same seed always gives the same
+// outcome. Milestones are measured by the given date, the end of the horizon when zero.
+func RunMonteCarlo(in Input, paths int, seed int64, by time.Time) *MonteCarlo {
+	rng := rand.New(rand.NewSource(seed))
+	months := generateMonths(in.Start, horizonEnd(in))
+
+	mean := in.Params.MonthlyReturnRate
+	sd := in.Params.Volatility / 100 / math.Sqrt(12)
+
+	byMonth := int64(len(months) - 1)
+	if !by.IsZero() {
+		byMonth = int64(MonthsBetween(in.Start, by))
+	}
+
+	keys := PeriodKeys(in)
+	assets := make(map[string][]float64, len(keys))
+	reached := make(map[string][]int64, len(Milestones))
+	for p := 0; p < paths; p++ {
+		in.returns = make([]float64, len(months))
+		for i := range in.returns {
+			in.returns[i] = math.Max(mean+sd*rng.NormFloat64(), -1)
+		}
+
+		res := Simulate(in)
+
+		// * assets stay at zero once the path went bankrupt
+		pathAssets, _ := res.Series(keys)
+		for i, key := range keys {
+			assets[key] = append(assets[key], pathAssets[i])
+		}
+
+		for _, milestone := range Milestones {
+			if month, ok := milestoneMonth(in, res, milestone); ok {
+				reached[milestone] = append(reached[milestone], month)
+			}
+		}
+	}
+
+	mc := &MonteCarlo{Paths: paths, Seed: seed}
+	for _, key := range keys {
+		values := assets[key]
+		sort.Float64s(values)
+
+		mc.Bands = append(mc.Bands, &model.AssetBand{
+			Key: key,
+			P10: roundFloat(percentile(values, 10)),
+			P50: roundFloat(percentile(values, 50)),
+			P90: roundFloat(percentile(values, 90)),
+		})
+	}
+
+	for _, milestone := range Milestones {
+		sorted := reached[milestone]
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		var count int
+		for _, month := range sorted {
+			if month <= byMonth {
+				count++
+			}
+		}
+
+		probability := &model.MilestoneProbability{
+			Milestone:   milestone,
+			By:          getMonthAndYear(in.Start, byMonth),
+			Probability: roundFloat(float64(count) / float64(paths)),
+		}
+
+		// * paths never reaching the milestone count as the latest ones
+		if median := (paths+1)/2 - 1; median < len(sorted) {
+			probability.MedianDate = getMonthAndYear(in.Start, sorted[median])
+		}
+
+		mc.Milestones = append(mc.Milestones, probability)
+	}
+
+	return mc
+}
+
+// milestoneMonth returns the month the milestone is reached within the horizon of the result
+func milestoneMonth(in Input, res *Result, milestone string) (int64, bool) {
+	switch milestone {
+	case MilestoneEmergencyFund:
+		return eventMonth(in, res.Events.EmergencyBudgetFilled)
+	case MilestoneFinancialFreedom:
+		// * a volatile path may lose it again, the first month counts
+		for i, node := range res.Nodes {
+			if node.IsAchivedFinancialFreedom {
+				return int64(i), true
+			}
+		}
+	case MilestoneMillionaire:
+		month, ok := firstMonthReaching(res, in.Params.MillionaireRate)
+		return int64(month), ok
+	}
+
+	return 0, false
+}
+
+// eventMonth returns the month of a forecast date, false when empty
+func eventMonth(in Input, date string) (int64, bool) {
+	d, err := time.Parse(DateFormat, date)
+	if err != nil {
+		return 0, false
+	}
+
+	return int64(MonthsBetween(in.Start, d)), true
+}
+
+// percentile returns the linearly interpolated percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
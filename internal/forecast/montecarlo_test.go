@@ -0,0 +1,45 @@
+package forecast
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// monteCarloInput returns a volatile five years forecast reaching its milestones on most paths
+func monteCarloInput() Input {
+	in := simulateInput(100000, 3000, 1000)
+	in.Params.Years = 5
+	in.Params.MonthlyReturnRate = 0.01
+	in.Params.Volatility = 15
+
+	return in
+}
+
+func TestRunMonteCarloSameSeed(t *testing.T) {
+	first := RunMonteCarlo(monteCarloInput(), 50, 42, time.Time{})
+	second := RunMonteCarlo(monteCarloInput(), 50, 42, time.Time{})
+
+	if !reflect.DeepEqual(first.Bands, second.Bands) {
+		t.Error("bands differ with the same seed")
+	}
+	if !reflect.DeepEqual(first.Milestones, second.Milestones) {
+		t.Error("milestones differ with the same seed")
+	}
+
+	for _, m := range first.Milestones {
+		if m.Probability > 0.5 && m.MedianDate == "" {
+			t.Errorf("%s reached on %v of the paths without a median date", m.Milestone, m.Probability)
+		}
+	}
+}
+
+func TestRunMonteCarloOtherSeed(t *testing.T) {
+	first := RunMonteCarlo(monteCarloInput(), 50, 42, time.Time{})
+	other := RunMonteCarlo(monteCarloInput(), 50, 7, time.Time{})
+
+	last := len(first.Bands) - 1
+	if reflect.DeepEqual(first.Bands[last], other.Bands[last]) {
+		t.Errorf("last band %+v is the same with another seed", first.Bands[last])
+	}
+}
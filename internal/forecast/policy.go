@@ -0,0 +1,46 @@
+package forecast
+
+import "dullahan/internal/model"
+
+// DefaultBudgetPolicy returns the budget policy of a new session
+func DefaultBudgetPolicy() model.BudgetPolicy {
+	return model.BudgetPolicy{
+		EmergencyFundMonths: EmergencyFundRate,
+		RainydayFundMonths:  RainydayFundRate,
+		FunFundRate:         FunFundRate * 100,
+		FunFundMode:         model.FunFundModeSpend,
+		RetirementPlanYears: RetirementPlanRate,
+		PaycheckCeil:        BankruptCeil,
+		GoodFlexibilityRate: GoodFlexibilityRate,
+		FreedomCovers:       model.FreedomCoversEssential,
+	}
+}
+
+// WithBudgetPolicy returns the params following the budget policy of a session
+func (p Params) WithBudgetPolicy(policy model.BudgetPolicy) Params {
+	p.EmergencyFundRate = policy.EmergencyFundMonths
+	p.RainydayFundRate = policy.RainydayFundMonths
+	p.FunFundRate = policy.FunFundRate / 100
+	p.FunFundCap = policy.FunFundCap
+	p.FunFundRollover = policy.FunFundMode == model.FunFundModeRollover
+	p.RetirementPlanRate = policy.RetirementPlanYears
+	p.BankruptCeil = policy.PaycheckCeil
+	p.GoodFlexibility = policy.GoodFlexibilityRate
+	p.FreedomCoversTotal = policy.FreedomCovers == model.FreedomCoversTotal
+
+	return p
+}
+
+// funFundAllocation returns the part of a positive surplus set aside for fun, capped by the policy
+func (p Params) funFundAllocation(surplus float64) float64 {
+	if surplus <= 0 {
+		return 0
+	}
+
+	amount := surplus * p.FunFundRate
+	if p.FunFundCap > 0 && amount > p.FunFundCap {
+		amount = p.FunFundCap
+	}
+
+	return roundFloat(amount)
+}
@@ -0,0 +1,157 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"math"
+	"time"
+)
+
+// Retire projects the savings of the input through retirement. Up to the retirement age the
+// forecast runs as usual, afterwards only passive incomes keep coming while the assets earn
+// the monthly return and pay for the expenses, the debts still owed and the fun fund until
+// the life expectancy. It returns nil when the birth date is unknown.
+func Retire(in Input) *model.RetirementProjection {
+	if in.BirthDate.IsZero() {
+		return nil
+	}
+
+	p := in.Params
+	retireAt := in.BirthDate.AddDate(p.RetirementAge, 0, 0)
+	endAt := in.BirthDate.AddDate(p.LifeExpectancy, 0, 0)
+	retireMonth := int64(math.Max(float64(MonthsBetween(in.Start, retireAt)), 0))
+	endMonth := int64(MonthsBetween(in.Start, endAt))
+
+	plan := &model.RetirementProjection{
+		RetirementDate:     getMonthAndYear(in.Start, retireMonth),
+		RetirementAge:      p.RetirementAge,
+		LifeExpectancy:     p.LifeExpectancy,
+		SafeWithdrawalRate: p.SafeWithdrawalRate,
+		IsSustainable:      true,
+	}
+
+	// * accumulation phase, the usual forecast up to the retirement month
+	acc := in
+	acc.Params.Years = int(math.Max(float64(retireAt.Year()-in.Start.Year()), 0))
+	res := Simulate(acc)
+
+	asset := in.CurrentBalance.Round(money.Cents)
+	balance := asset.Float64()
+	for i, node := range res.Nodes {
+		if int64(i) > retireMonth {
+			break
+		}
+
+		asset = money.FromFloat(node.CurrentAsset)
+		balance = node.CurrentAsset - node.TotalRemainingDebt
+		if i%12 == 0 {
+			plan.Balances = append(plan.Balances, newAgeBalance(in, int64(i), balance))
+		}
+	}
+
+	if res.Events.Bankrupt != "" && int64(len(res.Nodes)-1) < retireMonth {
+		return runOut(in, plan, int64(len(res.Nodes)-1))
+	}
+
+	t := Summarize(in)
+	retired := totalsAt(&in, t, retireMonth)
+	passive := retired.PassiveIncome
+
+	plan.SavingsAtRetirement = roundFloat(balance)
+	plan.MonthlyExpenseAtRetirement = retired.Expense
+	plan.MonthlyPassiveIncome = passive
+	if p.SafeWithdrawalRate > 0 {
+		plan.SafeMonthlyWithdrawal = roundFloat(math.Max(balance, 0) * p.SafeWithdrawalRate / 100 / 12)
+		plan.RequiredSavings = roundFloat(math.Max(retired.Expense-passive, 0) * 12 / (p.SafeWithdrawalRate / 100))
+	}
+
+	// * debts still owed at retirement keep being paid on their schedules
+	retiredAt := monthDate(in.Start, retireMonth)
+	var schedules []*debtSchedule
+	for _, debt := range OrderDebts(in.Debts, in.Strategy) {
+		if remaining := remainingAt(res, debt.ID, retireMonth); remaining > 0 {
+			v := *debt
+			v.RemainingAmount = money.FromFloat(remaining)
+			schedules = append(schedules, newDebtSchedule(&v, retiredAt))
+		}
+	}
+
+	// * drawdown phase, the assets pay for what passive incomes do not cover
+	for q := retireMonth + 1; q <= endMonth; q++ {
+		tq := totalsAt(&in, t, q)
+		prevAsset := asset
+		asset = asset.Add(money.FromFloat(tq.PassiveIncome - tq.Expense).Round(money.Cents))
+
+		var remainingDebt money.Decimal
+		for _, sch := range schedules {
+			if sch.remaining <= 0 {
+				continue
+			}
+
+			month := q - retireMonth
+			sch.reset(month)
+			payment, _ := sch.pay()
+			asset = asset.Sub(money.FromFloat(payment + sch.balloon(month)).Round(money.Cents))
+			remainingDebt = remainingDebt.Add(sch.remaining)
+		}
+
+		// * part of any surplus left after debts is set aside for fun, as before retirement
+		asset = asset.Sub(money.FromFloat(p.funFundAllocation(asset.Sub(prevAsset).Float64())))
+		asset = asset.Add(money.FromFloat(asset.Float64() * p.MonthlyReturnRate).Round(money.Cents))
+		balance = asset.Sub(remainingDebt).Float64()
+
+		if asset <= 0 {
+			return runOut(in, plan, q)
+		}
+
+		if (q-retireMonth)%12 == 0 {
+			plan.Balances = append(plan.Balances, newAgeBalance(in, q, balance))
+		}
+	}
+
+	return plan
+}
+
+// remainingAt returns the balance of the debt left after the given month of the forecast
+func remainingAt(res *Result, debtID, month int64) float64 {
+	var remaining float64
+	for _, node := range res.DebtNodes {
+		if node.DebtID == debtID && node.Month <= month {
+			remaining = node.RemainingAmount
+		}
+	}
+
+	return remaining
+}
+
+// runOut marks the month the savings are used up
+func runOut(in Input, plan *model.RetirementProjection, month int64) *model.RetirementProjection {
+	plan.IsSustainable = false
+	plan.MoneyRunsOutDate = getMonthAndYear(in.Start, month)
+	plan.MoneyRunsOutAge = AgeAt(in.BirthDate, monthDate(in.Start, month))
+
+	return plan
+}
+
+func newAgeBalance(in Input, month int64, balance float64) *model.AgeBalance {
+	return &model.AgeBalance{
+		Age:     AgeAt(in.BirthDate, monthDate(in.Start, month)),
+		Date:    getMonthAndYear(in.Start, month),
+		Balance: roundFloat(balance),
+	}
+}
+
+// monthDate returns the first day of the given month after the start
+func monthDate(start time.Time, month int64) time.Time {
+	return time.Date(start.Year(), start.Month()+time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// AgeAt returns the age in full years at the given date
+func AgeAt(birthDate, date time.Time) int {
+	age := date.Year() - birthDate.Year()
+	if date.Month() < birthDate.Month() || date.Month() == birthDate.Month() && date.Day() < birthDate.Day() {
+		age--
+	}
+
+	return age
+}
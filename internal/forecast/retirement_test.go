@@ -0,0 +1,59 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// retirementInput returns a forecast retiring on its first month and living two more years,
+// on a passive income and an essential expense
+func retirementInput(balance, passive, expense float64) Input {
+	in := simulateInput(balance, 0, expense)
+	in.Incomes = []*model.Income{{Amount: money.FromFloat(passive), Type: model.IncomeTypePassive, Schedule: model.NewSchedule("", nil)}}
+	in.BirthDate = time.Date(1961, 1, 1, 0, 0, 0, 0, time.UTC)
+	in.Params.RetirementAge = 65
+	in.Params.LifeExpectancy = 67
+
+	return in
+}
+
+func TestRetireSetsFunFundAside(t *testing.T) {
+	in := retirementInput(10000, 1500, 1000)
+	in.Params.FunFundRate = FunFundRate
+	plan := Retire(in)
+
+	// * 500 of surplus a month, 100 of it set aside for fun
+	if plan.SavingsAtRetirement != 10400 {
+		t.Errorf("savings at retirement = %v, want 10400", plan.SavingsAtRetirement)
+	}
+	var balances []float64
+	for _, b := range plan.Balances {
+		balances = append(balances, b.Balance)
+	}
+	if want := []float64{10400, 15200, 20000}; !reflect.DeepEqual(balances, want) {
+		t.Errorf("balances = %v, want %v", balances, want)
+	}
+}
+
+func TestRetirePaysRemainingDebts(t *testing.T) {
+	in := retirementInput(4000, 1000, 1000)
+	in.Debts = []*model.Debt{{
+		ID:              1,
+		Type:            model.DebtTypeFixed,
+		RemainingAmount: money.FromFloat(5000),
+		MonthlyPayment:  money.FromFloat(500),
+		AnnualInterest:  12,
+	}}
+	plan := Retire(in)
+
+	// * 3500 left after the first payment, 500 more paid each month until nothing is left in August
+	if plan.SavingsAtRetirement != -1050 {
+		t.Errorf("savings at retirement = %v, want -1050", plan.SavingsAtRetirement)
+	}
+	if plan.IsSustainable || plan.MoneyRunsOutDate != "Aug 2026" {
+		t.Errorf("money runs out = %q sustainable %v, want Aug 2026", plan.MoneyRunsOutDate, plan.IsSustainable)
+	}
+}
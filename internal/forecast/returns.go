@@ -0,0 +1,24 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"math"
+)
+
+// RiskProfile holds the investment return assumptions of a risk profile, in percent
+type RiskProfile struct {
+	AnnualReturn float64
+	Volatility   float64
+}
+
+// RiskProfiles lists the preset assumptions of each risk profile
+var RiskProfiles = map[string]RiskProfile{
+	model.SessionRiskProfileConservative: {AnnualReturn: 5.00, Volatility: 6.00},
+	model.SessionRiskProfileBalanced:     {AnnualReturn: ExpectedAnnualReturn, Volatility: ExpectedVolatility},
+	model.SessionRiskProfileAggressive:   {AnnualReturn: 16.00, Volatility: 22.00},
+}
+
+// MonthlyReturn converts an expected annual return in percent to the compounded monthly rate
+func MonthlyReturn(annualReturn float64) float64 {
+	return math.Pow(1+annualReturn/100, 1.0/12) - 1
+}
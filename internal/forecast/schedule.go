@@ -0,0 +1,141 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"math"
+	"time"
+)
+
+// hasSchedules reports whether any income or expense is not paid monthly
+func hasSchedules(in *Input) bool {
+	for _, income := range in.Incomes {
+		if isScheduled(income.Schedule) {
+			return true
+		}
+	}
+	for _, expense := range in.Expenses {
+		if isScheduled(expense.Schedule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MonthlyAmount returns the monthly equivalent of the scheduled amount
+func MonthlyAmount(amount float64, s model.Schedule) float64 {
+	return roundFloat(amount * s.MonthlyFactor())
+}
+
+func isScheduled(s model.Schedule) bool {
+	return s.Frequency != "" && s.Frequency != model.FrequencyMonthly
+}
+
+// flowsAt returns the incomes received and the expenses paid in the given month, gross
+// incomes are net of the effective tax rate
+func flowsAt(in *Input, t Totals, month int64) (income, expense float64) {
+	for _, inc := range in.Incomes {
+		if !activeIn(in, inc.Schedule, month) {
+			continue
+		}
+
+		raises := anniversaries(in.Start, raiseMonth(inc), month)
+		amount := inc.Amount.Float64() * math.Pow(1+inc.AnnualRaiseRate/100, float64(raises)) *
+			occurrences(inc.Schedule, anchorOf(inc.Schedule, inc.CreatedAt, in.Start), in.Start, month)
+		if inc.IsGross {
+			amount = amount * (1 - t.TaxRate)
+		}
+
+		income += amount
+	}
+
+	inflation := inflationFactor(in.Params.InflationRate, month)
+	for _, exp := range in.Expenses {
+		if !activeIn(in, exp.Schedule, month) {
+			continue
+		}
+
+		expense += exp.Amount.Float64() * inflation *
+			occurrences(exp.Schedule, anchorOf(exp.Schedule, exp.CreatedAt, in.Start), in.Start, month)
+	}
+
+	return roundFloat(income), roundFloat(expense)
+}
+
+// activeIn reports whether the scheduled amount counts in the given month, the start and
+// end months included
+func activeIn(in *Input, s model.Schedule, month int64) bool {
+	if s.StartDate != nil && int64(MonthsBetween(in.Start, time.Time(*s.StartDate))) > month {
+		return false
+	}
+	if s.EndDate != nil && int64(MonthsBetween(in.Start, time.Time(*s.EndDate))) < month {
+		return false
+	}
+
+	return true
+}
+
+// anchorOf returns a date the scheduled amount occurs on, the creation date by default
+func anchorOf(s model.Schedule, createdAt, start time.Time) time.Time {
+	switch {
+	case s.AnchorDate != nil:
+		return time.Time(*s.AnchorDate)
+	case !createdAt.IsZero():
+		return createdAt
+	default:
+		return start
+	}
+}
+
+// occurrences counts how many times the scheduled amount occurs in the given month
+func occurrences(s model.Schedule, anchor, start time.Time, month int64) float64 {
+	first := time.Date(start.Year(), start.Month()+time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	diff := int64(MonthsBetween(anchor, first))
+
+	switch s.Frequency {
+	case model.FrequencyWeekly:
+		return float64(occurrencesEvery(7, anchor, first))
+	case model.FrequencyBiweekly:
+		return float64(occurrencesEvery(14, anchor, first))
+	case model.FrequencySemiMonthly:
+		return 2
+	case model.FrequencyQuarterly:
+		if (diff%3+3)%3 == 0 {
+			return 1
+		}
+	case model.FrequencyAnnual:
+		if (diff%12+12)%12 == 0 {
+			return 1
+		}
+	case model.FrequencyOneTime:
+		if diff == 0 {
+			return 1
+		}
+	default:
+		return 1
+	}
+
+	return 0
+}
+
+// occurrencesEvery counts the days every given number of days from the anchor falling in the
+// month starting on the first day
+func occurrencesEvery(days int64, anchor, first time.Time) int64 {
+	day := func(t time.Time) int64 {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+	}
+
+	a := day(anchor)
+	from, to := day(first)-a, day(first.AddDate(0, 1, -1))-a
+
+	return floorDiv(to, days) - floorDiv(from-1, days)
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+
+	return q
+}
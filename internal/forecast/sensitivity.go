@@ -0,0 +1,185 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"math"
+	"sort"
+	"time"
+)
+
+// SensitivityMilestones lists the milestones a sensitivity analysis follows
+var SensitivityMilestones = []string{
+	MilestoneEmergencyFund, MilestoneDebtFree, MilestoneFinancialFreedom, MilestoneMillionaire,
+}
+
+// Step holds how far each input moves in a sensitivity analysis
+type Step struct {
+	Type string  // * PERCENT of the input or AMOUNT in the session currency
+	Size float64 // * in percent or in the session currency
+}
+
+// NewStep returns the step of the given type and size, 10 percent by default
+func NewStep(stepType string, size float64) Step {
+	step := Step{Type: stepType, Size: size}
+	if step.Type == "" {
+		step.Type = SensitivityStepPercent
+	}
+	if step.Size <= 0 {
+		step.Size = SensitivityPercent
+		if step.Type == SensitivityStepAmount {
+			step.Size = SensitivityAmount
+		}
+	}
+
+	return step
+}
+
+// move returns the value one step in the direction, amounts never go below zero.
+// Amount steps move the return rate by percentage points instead of currency units.
+func (s Step) move(value, direction float64, isRate bool) float64 {
+	var moved float64
+	switch {
+	case s.Type == SensitivityStepAmount && isRate:
+		moved = value + direction*SensitivityReturnPoints
+	case s.Type == SensitivityStepAmount:
+		moved = value + direction*s.Size
+	default:
+		moved = value * (1 + direction*s.Size/100)
+	}
+
+	if !isRate {
+		moved = math.Max(moved, 0)
+	}
+
+	return moved
+}
+
+// Sensitivity moves each income, expense, debt payment, the balance and the return rate
+// one step up then down, and reports how the milestone dates shift, the biggest impact first.
+// Incomes and expenses move by their monthly equivalent, whatever their frequency.
+func Sensitivity(in Input, step Step) []*model.SensitivityDriver {
+	baseline := simulateMilestones(in).Events
+	end := horizonEnd(in)
+
+	var drivers []*model.SensitivityDriver
+	drive := func(d *model.SensitivityDriver, isRate bool, apply func(moved *Input, value float64)) {
+		for _, direction := range []float64{1, -1} {
+			moved := in
+			value := step.move(d.Value, direction, isRate)
+			apply(&moved, value)
+			events := simulateMilestones(moved).Events
+
+			shift := &model.SensitivityShift{Value: roundFloat(value)}
+			for _, milestone := range SensitivityMilestones {
+				ms, months := milestoneShift(end, milestone, baseline.Date(milestone), events.Date(milestone))
+				shift.Milestones = append(shift.Milestones, ms)
+				d.Impact += months
+			}
+
+			if direction > 0 {
+				d.Up = shift
+			} else {
+				d.Down = shift
+			}
+		}
+
+		drivers = append(drivers, d)
+	}
+
+	for i, income := range in.Incomes {
+		factor := monthlyFactor(income.Schedule)
+		drive(&model.SensitivityDriver{
+			Driver: model.SensitivityDriverIncome,
+			ID:     income.ID,
+			Name:   income.Name,
+			Value:  roundFloat(income.Amount.Float64() * factor),
+		}, false, func(moved *Input, value float64) {
+			v := *income
+			v.Amount = money.FromFloat(value / factor)
+			moved.Incomes = append([]*model.Income{}, in.Incomes...)
+			moved.Incomes[i] = &v
+		})
+	}
+
+	for i, expense := range in.Expenses {
+		factor := monthlyFactor(expense.Schedule)
+		drive(&model.SensitivityDriver{
+			Driver: model.SensitivityDriverExpense,
+			ID:     expense.ID,
+			Name:   expense.Name,
+			Value:  roundFloat(expense.Amount.Float64() * factor),
+		}, false, func(moved *Input, value float64) {
+			v := *expense
+			v.Amount = money.FromFloat(value / factor)
+			moved.Expenses = append([]*model.Expense{}, in.Expenses...)
+			moved.Expenses[i] = &v
+		})
+	}
+
+	for i, debt := range in.Debts {
+		drive(&model.SensitivityDriver{
+			Driver: model.SensitivityDriverDebtPayment,
+			ID:     debt.ID,
+			Name:   debt.Name,
+			Value:  debt.MonthlyPayment.Float64(),
+		}, false, func(moved *Input, value float64) {
+			v := *debt
+			v.MonthlyPayment = money.FromFloat(value)
+			moved.Debts = append([]*model.Debt{}, in.Debts...)
+			moved.Debts[i] = &v
+		})
+	}
+
+	drive(&model.SensitivityDriver{
+		Driver: model.SensitivityDriverBalance,
+		Name:   model.SensitivityNameBalance,
+		Value:  in.CurrentBalance.Float64(),
+	}, false, func(moved *Input, value float64) {
+		moved.CurrentBalance = money.FromFloat(value)
+	})
+
+	drive(&model.SensitivityDriver{
+		Driver: model.SensitivityDriverReturnRate,
+		Name:   model.SensitivityNameReturnRate,
+		Value:  roundFloat((math.Pow(1+in.Params.MonthlyReturnRate, 12) - 1) * 100),
+	}, true, func(moved *Input, value float64) {
+		moved.Params.MonthlyReturnRate = MonthlyReturn(value)
+	})
+
+	sort.SliceStable(drivers, func(i, j int) bool { return drivers[i].Impact > drivers[j].Impact })
+
+	return drivers
+}
+
+// monthlyFactor returns how many times the amount occurs in a month, one-time amounts move as they are
+func monthlyFactor(schedule model.Schedule) float64 {
+	if factor := schedule.MonthlyFactor(); factor > 0 {
+		return factor
+	}
+
+	return 1
+}
+
+// milestoneShift compares the date of the milestone with the baseline one and returns how many
+// months it moved. A milestone reached on one side only counts as reached right after the horizon
+// on the other, when the reached side falls within the horizon, it is left out otherwise.
+func milestoneShift(end time.Time, milestone, baseline, date string) (*model.MilestoneShift, int) {
+	shift := &model.MilestoneShift{Milestone: milestone, Baseline: baseline, Date: date}
+
+	from, fromErr := time.Parse(DateFormat, baseline)
+	to, toErr := time.Parse(DateFormat, date)
+
+	var months int
+	switch {
+	case fromErr == nil && toErr == nil:
+		months = MonthsBetween(from, to)
+		shift.MonthsDiff = &months
+	case fromErr == nil && !from.After(end):
+		months = MonthsBetween(from, end) + 1
+	case toErr == nil && !to.After(end):
+		months = MonthsBetween(to, end) + 1
+	}
+
+	return shift, int(math.Abs(float64(months)))
+}
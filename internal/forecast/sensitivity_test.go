@@ -0,0 +1,57 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"testing"
+	"time"
+)
+
+func TestMilestoneShift(t *testing.T) {
+	end := time.Date(2027, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		baseline string
+		date     string
+		months   int
+		diff     bool
+	}{
+		{"earlier", "Jun 2026", "Mar 2026", 3, true},
+		{"later", "Mar 2026", "Jun 2026", 3, true},
+		{"both past the horizon", "Jan 2040", "Jan 2045", 60, true},
+		{"lost within the horizon", "Oct 2027", "", 3, false},
+		{"gained within the horizon", "", "Dec 2027", 1, false},
+		{"lost past the horizon", "Jan 2040", "", 0, false},
+		{"gained past the horizon", "", "Jan 2040", 0, false},
+		{"never reached", "", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		shift, months := milestoneShift(end, MilestoneMillionaire, tt.baseline, tt.date)
+		if months != tt.months || (shift.MonthsDiff != nil) != tt.diff {
+			t.Errorf("%s: months = %d, diff %v, want %d, diff %v", tt.name, months, shift.MonthsDiff != nil, tt.months, tt.diff)
+		}
+	}
+}
+
+func TestSensitivityStepsMonthlyAmounts(t *testing.T) {
+	in := simulateInput(1000, 600, 1200)
+	in.Incomes[0].Schedule = model.NewSchedule(model.FrequencyWeekly, nil)
+	in.Expenses[0].Schedule = model.NewSchedule(model.FrequencyAnnual, nil)
+
+	drivers := Sensitivity(in, NewStep(SensitivityStepAmount, 100))
+
+	// * 600 a week is 2,600 a month, 1,200 a year is 100 a month
+	want := map[string][2]float64{
+		model.SensitivityDriverIncome:  {2600, 2700},
+		model.SensitivityDriverExpense: {100, 200},
+	}
+	for _, d := range drivers {
+		w, ok := want[d.Driver]
+		if !ok {
+			continue
+		}
+		if d.Value != w[0] || d.Up.Value != w[1] {
+			t.Errorf("%s: value = %v, up = %v, want %v, %v", d.Driver, d.Value, d.Up.Value, w[0], w[1])
+		}
+	}
+}
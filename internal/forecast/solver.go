@@ -0,0 +1,85 @@
+package forecast
+
+import "math"
+
+// MonthsToReach returns how many months of steady growth take the balance to the target.
+// Each month the net flow is added, then everything above the reserve earns the monthly
+// return, just like the simulation does once investing has started. It returns false
+// when the target is not reached within MaxSolverYears.
+func MonthsToReach(balance, target, monthlyNetFlow, reserve, monthlyReturnRate float64) (int, bool) {
+	if balance >= target {
+		return 0, true
+	}
+
+	// * invested part of the balance, grows as b' = (b + f) * (1 + g)
+	b, t := balance-reserve, target-reserve
+	f, g := monthlyNetFlow, monthlyReturnRate
+
+	var months float64
+	if g == 0 {
+		if f <= 0 {
+			return 0, false
+		}
+		months = math.Ceil((t - b) / f)
+	} else {
+		// * b(n) = (b + c) * (1 + g)^n - c
+		c := f * (1 + g) / g
+		if b+c <= 0 || t+c <= 0 {
+			return 0, false
+		}
+		months = math.Ceil(math.Log((t+c)/(b+c)) / math.Log(1+g))
+	}
+
+	if months <= 0 || months > MaxSolverYears*12 {
+		return 0, false
+	}
+
+	return int(months), true
+}
+
+// ReachDate returns the first month the net worth of the forecast crosses the threshold.
+// Past the horizon, it extends the steady growth of the last month, or simulates further
+// when debts, funds or goals are still in progress or the monthly net flow keeps changing.
+// It returns empty when never reached.
+func ReachDate(in Input, res *Result, threshold float64) string {
+	if month, ok := firstMonthReaching(res, threshold); ok {
+		return getMonthAndYear(in.Start, int64(month))
+	}
+
+	if len(res.Nodes) == 0 || res.Events.Bankrupt != "" {
+		return ""
+	}
+
+	last := len(res.Nodes) - 1
+	node := res.Nodes[last]
+	if !node.IsPaidAllDebt || !node.IsAchivedInvestment || hasOpenGoals(&in, res) || hasGrowth(&in) || hasSchedules(&in) {
+		if in.Params.Years >= MaxSolverYears {
+			return ""
+		}
+
+		in.Params.Years = MaxSolverYears
+		if month, ok := firstMonthReaching(Simulate(in), threshold); ok {
+			return getMonthAndYear(in.Start, int64(month))
+		}
+		return ""
+	}
+
+	// * the fun fund is set aside every month before anything is invested
+	reserve := node.ActualEmergencyFund + node.ActualRainydayFund
+	months, ok := MonthsToReach(node.CurrentAsset, threshold, node.MonthlyNetFlow-node.ExpectFunFund, reserve, in.Params.MonthlyReturnRate)
+	if !ok {
+		return ""
+	}
+
+	return getMonthAndYear(in.Start, int64(last+months))
+}
+
+func firstMonthReaching(res *Result, threshold float64) (int, bool) {
+	for i, node := range res.Nodes {
+		if node.CurrentAsset-node.TotalRemainingDebt >= threshold {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,52 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+func TestMonthsToReach(t *testing.T) {
+	tests := []struct {
+		name                                 string
+		balance, target, flow, reserve, rate float64
+		months                               int
+		ok                                   bool
+	}{
+		// * c = 1,000 * 1.01 / 0.01 = 101,000, (100,000 + c) * 1.01^69 - c = 298,365.78
+		// and (100,000 + c) * 1.01^70 - c = 302,359.44
+		{"growth", 100000, 300000, 1000, 0, 0.01, 70, true},
+		{"growth above the reserve", 110000, 310000, 1000, 10000, 0.01, 70, true},
+		{"no growth", 1000, 10000, 1000, 0, 0, 9, true},
+		{"already there", 10000, 10000, 0, 0, 0, 0, true},
+		{"no flow", 1000, 10000, 0, 0, 0, 0, false},
+		{"shrinking", 100000, 300000, -2000, 0, 0.01, 0, false},
+		{"too far", 0, 1000000, 1, 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		months, ok := MonthsToReach(tt.balance, tt.target, tt.flow, tt.reserve, tt.rate)
+		if months != tt.months || ok != tt.ok {
+			t.Errorf("%s: months = %d, %v, want %d, %v", tt.name, months, ok, tt.months, tt.ok)
+		}
+	}
+}
+
+func TestReachDateFundsGoals(t *testing.T) {
+	in := simulateInput(100000, 3000, 1000)
+
+	// * the goal takes the whole 2,000 surplus from Jan 2026 to Dec 2028, past the horizon
+	in.Goals = []*model.Goal{{ID: 1, Name: "House", TargetAmount: money.FromFloat(72000), TargetDate: datatypes.Date(time.Date(2028, 12, 31, 0, 0, 0, 0, time.UTC))}}
+	res := Simulate(in)
+	if _, ok := res.Events.GoalReached[1]; ok {
+		t.Fatal("goal reached within the horizon, want still funded")
+	}
+
+	// * the balance then grows by 2,000 a month from Jan 2029, the 50th month is Feb 2033
+	if got := ReachDate(in, res, 200000); got != "Feb 2033" {
+		t.Errorf("reach date = %q, want Feb 2033", got)
+	}
+}
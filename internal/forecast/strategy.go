@@ -0,0 +1,81 @@
+package forecast
+
+import (
+	"dullahan/internal/model"
+	"sort"
+)
+
+// Strategies lists the available debt payoff strategies
+var Strategies = []string{model.DebtStrategyAvalanche, model.DebtStrategySnowball, model.DebtStrategyCustom}
+
+// OrderDebts returns a copy of the debts in the payoff order of the strategy
+func OrderDebts(debts []*model.Debt, strategy string) []*model.Debt {
+	ordered := append([]*model.Debt{}, debts...)
+
+	avalanche := func(a, b *model.Debt) bool {
+		if a.AnnualInterest != b.AnnualInterest {
+			return a.AnnualInterest > b.AnnualInterest
+		}
+		return a.RemainingAmount < b.RemainingAmount
+	}
+
+	var less func(a, b *model.Debt) bool
+	switch strategy {
+	case model.DebtStrategySnowball:
+		less = func(a, b *model.Debt) bool {
+			if a.RemainingAmount != b.RemainingAmount {
+				return a.RemainingAmount < b.RemainingAmount
+			}
+			return a.AnnualInterest > b.AnnualInterest
+		}
+	case model.DebtStrategyCustom:
+		// * prioritized debts first, the others fall back to avalanche
+		less = func(a, b *model.Debt) bool {
+			switch {
+			case a.Priority > 0 && b.Priority > 0 && a.Priority != b.Priority:
+				return a.Priority < b.Priority
+			case a.Priority > 0 && b.Priority <= 0:
+				return true
+			case a.Priority <= 0 && b.Priority > 0:
+				return false
+			}
+			return avalanche(a, b)
+		}
+	default:
+		less = avalanche
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return less(ordered[i], ordered[j])
+	})
+
+	return ordered
+}
+
+// CompareStrategies runs the forecast once per strategy and summarizes how the debts are paid off
+func CompareStrategies(in Input) []*model.StrategyComparison {
+	comparisons := make([]*model.StrategyComparison, 0, len(Strategies))
+	for _, strategy := range Strategies {
+		in.Strategy = strategy
+		res := Run(in)
+
+		comparison := &model.StrategyComparison{
+			Strategy:          strategy,
+			TotalInterestPaid: roundFloat(res.TotalInterestPaid()),
+			DebtFreeDate:      res.Events.DebtFree,
+		}
+
+		for _, debt := range OrderDebts(in.Debts, strategy) {
+			comparison.Debts = append(comparison.Debts, &model.DebtPayoff{
+				DebtID:       debt.ID,
+				Name:         debt.Name,
+				PaidOffDate:  res.Events.DebtPaidOff[debt.ID],
+				InterestPaid: roundFloat(res.InterestPaid(debt.ID)),
+			})
+		}
+
+		comparisons = append(comparisons, comparison)
+	}
+
+	return comparisons
+}
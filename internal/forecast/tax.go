@@ -0,0 +1,32 @@
+package forecast
+
+import "dullahan/internal/model"
+
+// Tax returns the monthly taxes of the gross incomes of the input, nil without a tax calculator
+func Tax(in Input) *model.TaxLineItem {
+	if in.Tax == nil {
+		return nil
+	}
+
+	var gross float64
+	for _, income := range in.Incomes {
+		if income.IsGross && activeIn(&in, income.Schedule, 0) {
+			gross += income.Amount.Float64() * income.MonthlyFactor()
+		}
+	}
+
+	return in.Tax.Calculate(gross)
+}
+
+// applyTax withholds the taxes of the gross incomes, passive ones at the effective rate
+func applyTax(in *Input, t *Totals, gross, grossPassive float64) {
+	if in.Tax == nil || gross <= 0 {
+		return
+	}
+
+	item := in.Tax.Calculate(gross)
+	t.Tax = item.TotalTax
+	t.TaxRate = item.EffectiveRate / 100
+	t.Income = roundFloat(t.Income - item.TotalTax)
+	t.PassiveIncome = roundFloat(t.PassiveIncome - grossPassive*item.EffectiveRate/100)
+}
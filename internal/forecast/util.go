@@ -0,0 +1,99 @@
+package forecast
+
+import (
+	"dullahan/internal/money"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/golang-module/carbon/v2"
+)
+
+func generateMonths(startDate, endDate time.Time) []int64 {
+	months := []int64{}
+
+	// * count calendar months, 30-day months drift away on long horizons
+	for i := 0; i <= MonthsBetween(startDate, endDate); i++ {
+		months = append(months, int64(i))
+	}
+
+	return months
+}
+
+func quarterOf(month int) int {
+	quarter := math.Ceil(float64(month) / 3)
+	return int(quarter)
+}
+
+func getQuarter(d time.Time, i int64) string {
+	c := carbon.FromStdTime(d)
+	c = c.SetDay(26)
+
+	nextDate := c.AddMonths(int(i))
+	year := nextDate.Year()
+	quarter := quarterOf(nextDate.Month())
+
+	return fmt.Sprintf("%dQ%d", year, quarter)
+}
+
+func getYear(d time.Time, i int64) string {
+	c := carbon.FromStdTime(d)
+	c = c.SetDay(26)
+
+	return fmt.Sprintf("%d", c.AddMonths(int(i)).Year())
+}
+
+// getPeriod returns the chart key of the i-th month for the given granularity
+func getPeriod(d time.Time, i int64, granularity string) string {
+	switch granularity {
+	case GranularityQuarterly:
+		return getQuarter(d, i)
+	case GranularityYearly:
+		return getYear(d, i)
+	default:
+		return getMonth(d, i)
+	}
+}
+
+// FormatPeriod formats a date as the period it falls in for the given granularity
+func FormatPeriod(d time.Time, granularity string) string {
+	switch granularity {
+	case GranularityQuarterly, GranularityYearly:
+		return getPeriod(d, 0, granularity)
+	default:
+		return d.Format(DateFormat)
+	}
+}
+
+func getMonth(d time.Time, i int64) string {
+	c := carbon.FromStdTime(d)
+	c = c.SetDay(26)
+
+	nextDate := c.AddMonths(int(i))
+	year := nextDate.Year()
+	month := nextDate.ToMonthString()[0:3]
+
+	// year%1e2
+
+	return fmt.Sprintf("%s-%d", month, year)
+}
+
+func getMonthAndYear(d time.Time, i int64) string {
+	c := carbon.FromStdTime(d)
+	c = c.SetDay(26)
+
+	nextDate := c.AddMonths(int(i))
+	year := nextDate.Year()
+	month := nextDate.ToMonthString()[0:3]
+
+	return fmt.Sprintf("%s %d", month, year)
+}
+
+func roundFloat(num float64) float64 {
+	return money.RoundFloat(num, money.Cents)
+}
+
+// MonthsBetween returns the calendar months from the start date to the end date, negative when the end comes first
+func MonthsBetween(startDate, endDate time.Time) int {
+	return (endDate.Year()-startDate.Year())*12 + int(endDate.Month()) - int(startDate.Month())
+}
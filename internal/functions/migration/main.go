@@ -263,6 +263,463 @@ func Run() (respErr error) {
 				return migration.ExecMultiple(tx, strings.Join(changes, " "))
 			},
 		},
+		// widen column "type" of debts table to fit the amortized types
+		{
+			ID: "202610181000",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE debts ALTER COLUMN type TYPE VARCHAR(20);`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE debts ALTER COLUMN type TYPE VARCHAR(10);`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// create table "debt_rates"
+		{
+			ID: "202610181100",
+			Migrate: func(tx *gorm.DB) error {
+				type DebtRate struct {
+					Base
+					DebtID         int64          `json:"debt_id" gorm:"index"`
+					Type           string         `json:"type" gorm:"type:varchar(10);default:RATE"` // RATE, INDEX
+					EffectiveDate  datatypes.Date `json:"effective_date"`
+					AnnualInterest float64        `json:"annual_interest"`
+					IndexRate      float64        `json:"index_rate"`
+					Margin         float64        `json:"margin"`
+					Cap            *float64       `json:"cap"`
+					Floor          *float64       `json:"floor"`
+				}
+
+				return tx.Set("gorm:table_options", defaultTableOpts).AutoMigrate(&DebtRate{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("debt_rates")
+			},
+		},
+		// add column "debt_strategy" to sessions table, "priority" to debts table
+		{
+			ID: "202610181200",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN debt_strategy VARCHAR(10) DEFAULT 'AVALANCHE';`,
+					`ALTER TABLE debts ADD COLUMN priority INTEGER DEFAULT 0;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN debt_strategy;`,
+					`ALTER TABLE debts DROP COLUMN priority;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// add investment return assumptions to sessions table
+		{
+			ID: "202610181300",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN risk_profile VARCHAR(20) DEFAULT 'BALANCED';`,
+					`ALTER TABLE sessions ADD COLUMN expected_annual_return DECIMAL DEFAULT 12.23;`,
+					`ALTER TABLE sessions ADD COLUMN expected_volatility DECIMAL DEFAULT 15;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN risk_profile;`,
+					`ALTER TABLE sessions DROP COLUMN expected_annual_return;`,
+					`ALTER TABLE sessions DROP COLUMN expected_volatility;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// add column "inflation_rate" to sessions table, raise columns to incomes table
+		{
+			ID: "202610181400",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN inflation_rate DECIMAL DEFAULT 0;`,
+					`ALTER TABLE incomes ADD COLUMN annual_raise_rate DECIMAL DEFAULT 0;`,
+					`ALTER TABLE incomes ADD COLUMN raise_month INTEGER DEFAULT 0;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN inflation_rate;`,
+					`ALTER TABLE incomes DROP COLUMN annual_raise_rate;`,
+					`ALTER TABLE incomes DROP COLUMN raise_month;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// create scenarios table
+		{
+			ID: "202610181500",
+			Migrate: func(tx *gorm.DB) error {
+				type Scenario struct {
+					Base
+					SessionID int64          `json:"session_id" gorm:"index"`
+					Name      string         `json:"name" gorm:"type:varchar(100)"`
+					Overrides datatypes.JSON `json:"overrides"`
+				}
+
+				return tx.Set("gorm:table_options", defaultTableOpts).AutoMigrate(&Scenario{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("scenarios")
+			},
+		},
+		// create goals table
+		{
+			ID: "202610181600",
+			Migrate: func(tx *gorm.DB) error {
+				type Goal struct {
+					Base
+					SessionID              int64          `json:"session_id" gorm:"index"`
+					Name                   string         `json:"name" gorm:"type:varchar(100)"`
+					TargetAmount           float64        `json:"target_amount"`
+					TargetDate             datatypes.Date `json:"target_date"`
+					Priority               int            `json:"priority" gorm:"default:0"`
+					ForecastCompletionDate string         `json:"forecast_completion_date" gorm:"type:varchar(50)"`
+					ForecastStatus         string         `json:"forecast_status" gorm:"type:varchar(10)"`
+				}
+
+				return tx.Set("gorm:table_options", defaultTableOpts).AutoMigrate(&Goal{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("goals")
+			},
+		},
+		// add budget policy columns to sessions table
+		{
+			ID: "202610181700",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN policy_emergency_fund_months DOUBLE PRECISION DEFAULT 6;`,
+					`ALTER TABLE sessions ADD COLUMN policy_rainyday_fund_months DOUBLE PRECISION DEFAULT 3;`,
+					`ALTER TABLE sessions ADD COLUMN policy_fun_fund_rate DOUBLE PRECISION DEFAULT 20;`,
+					`ALTER TABLE sessions ADD COLUMN policy_retirement_plan_years DOUBLE PRECISION DEFAULT 10;`,
+					`ALTER TABLE sessions ADD COLUMN policy_paycheck_ceil DOUBLE PRECISION DEFAULT 200;`,
+					`ALTER TABLE sessions ADD COLUMN policy_good_flexibility_rate DOUBLE PRECISION DEFAULT 1;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN policy_emergency_fund_months;`,
+					`ALTER TABLE sessions DROP COLUMN policy_rainyday_fund_months;`,
+					`ALTER TABLE sessions DROP COLUMN policy_fun_fund_rate;`,
+					`ALTER TABLE sessions DROP COLUMN policy_retirement_plan_years;`,
+					`ALTER TABLE sessions DROP COLUMN policy_paycheck_ceil;`,
+					`ALTER TABLE sessions DROP COLUMN policy_good_flexibility_rate;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// backfill the essential and non essential expense totals of sessions
+		{
+			ID: "202610181800",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`UPDATE sessions SET total_essential_expense = COALESCE((SELECT SUM(amount) FROM expenses WHERE expenses.session_id = sessions.id AND expenses.type = 'ESSENTIAL'), 0);`,
+					`UPDATE sessions SET total_non_essential_expense = COALESCE((SELECT SUM(amount) FROM expenses WHERE expenses.session_id = sessions.id AND expenses.type = 'NON_ESSENTIAL'), 0);`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return nil
+			},
+		},
+		// add fun fund cap and mode to sessions table
+		{
+			ID: "202610181900",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN policy_fun_fund_cap DOUBLE PRECISION DEFAULT 0;`,
+					`ALTER TABLE sessions ADD COLUMN policy_fun_fund_mode VARCHAR(15) DEFAULT 'SPEND';`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN policy_fun_fund_cap;`,
+					`ALTER TABLE sessions DROP COLUMN policy_fun_fund_mode;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// add retirement planning to sessions table
+		{
+			ID: "202610182000",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN birth_date DATE;`,
+					`ALTER TABLE sessions ADD COLUMN retirement_age INTEGER DEFAULT 65;`,
+					`ALTER TABLE sessions ADD COLUMN life_expectancy INTEGER DEFAULT 90;`,
+					`ALTER TABLE sessions ADD COLUMN safe_withdrawal_rate DOUBLE PRECISION DEFAULT 4;`,
+					`ALTER TABLE sessions ADD COLUMN forecast_retirement_date VARCHAR(50);`,
+					`ALTER TABLE sessions ADD COLUMN forecast_money_runs_out_date VARCHAR(50);`,
+					`ALTER TABLE sessions ADD COLUMN forecast_money_runs_out_age INTEGER DEFAULT 0;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN birth_date;`,
+					`ALTER TABLE sessions DROP COLUMN retirement_age;`,
+					`ALTER TABLE sessions DROP COLUMN life_expectancy;`,
+					`ALTER TABLE sessions DROP COLUMN safe_withdrawal_rate;`,
+					`ALTER TABLE sessions DROP COLUMN forecast_retirement_date;`,
+					`ALTER TABLE sessions DROP COLUMN forecast_money_runs_out_date;`,
+					`ALTER TABLE sessions DROP COLUMN forecast_money_runs_out_age;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// add passive income total and financial freedom cover to sessions table
+		{
+			ID: "202610182100",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN total_passive_income DOUBLE PRECISION DEFAULT 0;`,
+					`ALTER TABLE sessions ADD COLUMN policy_freedom_covers VARCHAR(15) DEFAULT 'ESSENTIAL';`,
+					`UPDATE sessions SET total_passive_income = COALESCE((SELECT SUM(amount) FROM incomes WHERE incomes.session_id = sessions.id AND incomes.type = 'PASSIVE'), 0);`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN total_passive_income;`,
+					`ALTER TABLE sessions DROP COLUMN policy_freedom_covers;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// add gross incomes and tax rules
+		{
+			ID: "202610182200",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes ADD COLUMN is_gross BOOLEAN DEFAULT FALSE;`,
+					`ALTER TABLE sessions ADD COLUMN tax_region VARCHAR(10);`,
+					`ALTER TABLE sessions ADD COLUMN tax_rule_version VARCHAR(20);`,
+					`ALTER TABLE sessions ADD COLUMN total_gross_income DOUBLE PRECISION DEFAULT 0;`,
+					`ALTER TABLE sessions ADD COLUMN total_income_tax DOUBLE PRECISION DEFAULT 0;`,
+					`ALTER TABLE sessions ADD COLUMN effective_tax_rate DOUBLE PRECISION DEFAULT 0;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes DROP COLUMN is_gross;`,
+					`ALTER TABLE sessions DROP COLUMN tax_region;`,
+					`ALTER TABLE sessions DROP COLUMN tax_rule_version;`,
+					`ALTER TABLE sessions DROP COLUMN total_gross_income;`,
+					`ALTER TABLE sessions DROP COLUMN total_income_tax;`,
+					`ALTER TABLE sessions DROP COLUMN effective_tax_rate;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// add income and expense frequencies
+		{
+			ID: "202610182300",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes ADD COLUMN frequency VARCHAR(15) DEFAULT 'MONTHLY';`,
+					`ALTER TABLE incomes ADD COLUMN anchor_date DATE;`,
+					`ALTER TABLE expenses ADD COLUMN frequency VARCHAR(15) DEFAULT 'MONTHLY';`,
+					`ALTER TABLE expenses ADD COLUMN anchor_date DATE;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes DROP COLUMN frequency;`,
+					`ALTER TABLE incomes DROP COLUMN anchor_date;`,
+					`ALTER TABLE expenses DROP COLUMN frequency;`,
+					`ALTER TABLE expenses DROP COLUMN anchor_date;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// add income and expense start and end dates
+		{
+			ID: "202610182400",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes ADD COLUMN start_date DATE;`,
+					`ALTER TABLE incomes ADD COLUMN end_date DATE;`,
+					`ALTER TABLE expenses ADD COLUMN start_date DATE;`,
+					`ALTER TABLE expenses ADD COLUMN end_date DATE;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes DROP COLUMN start_date;`,
+					`ALTER TABLE incomes DROP COLUMN end_date;`,
+					`ALTER TABLE expenses DROP COLUMN start_date;`,
+					`ALTER TABLE expenses DROP COLUMN end_date;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// create exchange_rates table
+		{
+			ID: "202610182500",
+			Migrate: func(tx *gorm.DB) error {
+				type ExchangeRate struct {
+					Base
+					Currency      string         `json:"currency" gorm:"type:varchar(3);uniqueIndex:idx_exchange_rates_version"`
+					Rate          float64        `json:"rate"`
+					EffectiveDate datatypes.Date `json:"effective_date" gorm:"uniqueIndex:idx_exchange_rates_version"`
+				}
+
+				return tx.Set("gorm:table_options", defaultTableOpts).AutoMigrate(&ExchangeRate{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("exchange_rates")
+			},
+		},
+		// add currency columns to sessions, incomes, expenses and debts tables
+		{
+			ID: "202610182600",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions ADD COLUMN currency VARCHAR(3) DEFAULT 'USD';`,
+					`ALTER TABLE incomes ADD COLUMN currency VARCHAR(3);`,
+					`ALTER TABLE expenses ADD COLUMN currency VARCHAR(3);`,
+					`ALTER TABLE debts ADD COLUMN currency VARCHAR(3);`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE sessions DROP COLUMN currency;`,
+					`ALTER TABLE incomes DROP COLUMN currency;`,
+					`ALTER TABLE expenses DROP COLUMN currency;`,
+					`ALTER TABLE debts DROP COLUMN currency;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// store money columns of sessions, incomes, expenses, debts and goals as NUMERIC
+		{
+			ID: "202610182700",
+			Migrate: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes ALTER COLUMN amount TYPE NUMERIC(18,4) USING amount::numeric;`,
+					`ALTER TABLE expenses ALTER COLUMN amount TYPE NUMERIC(18,4) USING amount::numeric;`,
+					`ALTER TABLE debts ALTER COLUMN remaining_amount TYPE NUMERIC(18,4) USING remaining_amount::numeric;`,
+					`ALTER TABLE debts ALTER COLUMN monthly_payment TYPE NUMERIC(18,4) USING monthly_payment::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_all_income TYPE NUMERIC(18,4) USING total_all_income::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_passive_income TYPE NUMERIC(18,4) USING total_passive_income::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_gross_income TYPE NUMERIC(18,4) USING total_gross_income::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_income_tax TYPE NUMERIC(18,4) USING total_income_tax::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_all_expense TYPE NUMERIC(18,4) USING total_all_expense::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_monthly_payment_debt TYPE NUMERIC(18,4) USING total_monthly_payment_debt::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_essential_expense TYPE NUMERIC(18,4) USING total_essential_expense::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN total_non_essential_expense TYPE NUMERIC(18,4) USING total_non_essential_expense::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN monthly_net_flow TYPE NUMERIC(18,4) USING monthly_net_flow::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN current_balance TYPE NUMERIC(18,4) USING current_balance::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN actual_emergency_fund TYPE NUMERIC(18,4) USING actual_emergency_fund::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN expected_emergency_fund TYPE NUMERIC(18,4) USING expected_emergency_fund::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN actual_rainyday_fund TYPE NUMERIC(18,4) USING actual_rainyday_fund::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN expected_rainyday_fund TYPE NUMERIC(18,4) USING expected_rainyday_fund::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN actual_fun_fund TYPE NUMERIC(18,4) USING actual_fun_fund::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN expected_fun_fund TYPE NUMERIC(18,4) USING expected_fun_fund::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN investment TYPE NUMERIC(18,4) USING investment::numeric;`,
+					`ALTER TABLE sessions ALTER COLUMN retirement_plan TYPE NUMERIC(18,4) USING retirement_plan::numeric;`,
+					`ALTER TABLE goals ALTER COLUMN target_amount TYPE NUMERIC(18,4) USING target_amount::numeric;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+			Rollback: func(tx *gorm.DB) error {
+				changes := []string{
+					`ALTER TABLE incomes ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount::double precision;`,
+					`ALTER TABLE expenses ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount::double precision;`,
+					`ALTER TABLE debts ALTER COLUMN remaining_amount TYPE DOUBLE PRECISION USING remaining_amount::double precision;`,
+					`ALTER TABLE debts ALTER COLUMN monthly_payment TYPE DOUBLE PRECISION USING monthly_payment::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_all_income TYPE DOUBLE PRECISION USING total_all_income::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_passive_income TYPE DOUBLE PRECISION USING total_passive_income::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_gross_income TYPE DOUBLE PRECISION USING total_gross_income::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_income_tax TYPE DOUBLE PRECISION USING total_income_tax::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_all_expense TYPE DOUBLE PRECISION USING total_all_expense::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_monthly_payment_debt TYPE DOUBLE PRECISION USING total_monthly_payment_debt::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_essential_expense TYPE DOUBLE PRECISION USING total_essential_expense::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN total_non_essential_expense TYPE DOUBLE PRECISION USING total_non_essential_expense::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN monthly_net_flow TYPE DOUBLE PRECISION USING monthly_net_flow::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN current_balance TYPE DOUBLE PRECISION USING current_balance::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN actual_emergency_fund TYPE DOUBLE PRECISION USING actual_emergency_fund::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN expected_emergency_fund TYPE DOUBLE PRECISION USING expected_emergency_fund::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN actual_rainyday_fund TYPE DOUBLE PRECISION USING actual_rainyday_fund::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN expected_rainyday_fund TYPE DOUBLE PRECISION USING expected_rainyday_fund::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN actual_fun_fund TYPE DOUBLE PRECISION USING actual_fun_fund::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN expected_fun_fund TYPE DOUBLE PRECISION USING expected_fun_fund::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN investment TYPE DOUBLE PRECISION USING investment::double precision;`,
+					`ALTER TABLE sessions ALTER COLUMN retirement_plan TYPE DOUBLE PRECISION USING retirement_plan::double precision;`,
+					`ALTER TABLE goals ALTER COLUMN target_amount TYPE DOUBLE PRECISION USING target_amount::double precision;`,
+				}
+
+				return migration.ExecMultiple(tx, strings.Join(changes, " "))
+			},
+		},
+		// create health_scores table
+		{
+			ID: "202610182800",
+			Migrate: func(tx *gorm.DB) error {
+				type HealthScore struct {
+					Base
+					SessionID  int64          `json:"session_id" gorm:"uniqueIndex:idx_health_scores_day"`
+					RecordedOn datatypes.Date `json:"recorded_on" gorm:"uniqueIndex:idx_health_scores_day"`
+
+					Score                 int `json:"score"`
+					SavingsRateScore      int `json:"savings_rate_score"`
+					DebtToIncomeScore     int `json:"debt_to_income_score"`
+					EmergencyFundScore    int `json:"emergency_fund_score"`
+					HighInterestDebtScore int `json:"high_interest_debt_score"`
+				}
+
+				return tx.Set("gorm:table_options", defaultTableOpts).AutoMigrate(&HealthScore{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("health_scores")
+			},
+		},
 	})
 
 	return nil
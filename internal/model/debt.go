@@ -1,6 +1,7 @@
 package model
 
 import (
+	"dullahan/internal/money"
 	"time"
 
 	"gorm.io/datatypes"
@@ -14,13 +15,105 @@ type Debt struct {
 	SessionID int64     `json:"session_id"`
 
 	Name            string         `json:"name" gorm:"type:varchar(50)"`
-	RemainingAmount float64        `json:"remaining_amount"`
-	MonthlyPayment  float64        `json:"monthly_payment"`
+	RemainingAmount money.Decimal  `json:"remaining_amount"`
+	MonthlyPayment  money.Decimal  `json:"monthly_payment"`
 	AnnualInterest  float64        `json:"annual_interest"`
-	Type            string         `json:"type" gorm:"type:varchar(10);default:FIXED"` // FIXED, FIXED_AMORTIZED, FLOAT, FLOAT_AMORTIZED
+	Type            string         `json:"type" gorm:"type:varchar(20);default:FIXED"` // FIXED, FIXED_AMORTIZED, FLOAT, FLOAT_AMORTIZED
 	PaymentDeadline datatypes.Date `json:"payment_deadline" gorm:"default:NULL"`
+	Priority        int            `json:"priority" gorm:"default:0"` // * payoff order for CUSTOM strategy, 0 means unset
+
+	Currency                 string  `json:"currency" gorm:"type:varchar(3)"` // * the session currency when empty
+	ExchangeRate             float64 `json:"exchange_rate" gorm:"-"`          // * to the session currency
+	ConvertedRemainingAmount float64 `json:"converted_remaining_amount" gorm:"-"`
+	ConvertedMonthlyPayment  float64 `json:"converted_monthly_payment" gorm:"-"`
 
 	ForecastPaidOffDate string `json:"forecast_paid_off_date" gorm:"type:varchar(50)"`
 
-	Session *Session `json:"session,omitempty"`
+	RequiredMonthlyPayment float64 `json:"required_monthly_payment" gorm:"-"` // * to clear the debt by its payment deadline
+	ForecastBalloonPayment float64 `json:"forecast_balloon_payment" gorm:"-"` // * due at once at the payment deadline in the forecast
+	IsBehindDeadline       bool    `json:"is_behind_deadline" gorm:"-"`
+
+	Session *Session    `json:"session,omitempty"`
+	Rates   []*DebtRate `json:"rates,omitempty"`
+}
+
+// StrategyComparison represents the outcome of a debt payoff strategy
+// swagger:model
+type StrategyComparison struct {
+	Strategy          string        `json:"strategy"`
+	TotalInterestPaid float64       `json:"total_interest_paid"`
+	DebtFreeDate      string        `json:"debt_free_date"`
+	Debts             []*DebtPayoff `json:"debts"`
+}
+
+// DebtPayoff represents when a debt is paid off under a strategy
+// swagger:model
+type DebtPayoff struct {
+	DebtID       int64   `json:"debt_id"`
+	Name         string  `json:"name"`
+	PaidOffDate  string  `json:"paid_off_date"`
+	InterestPaid float64 `json:"interest_paid"`
+}
+
+// IsAmortized reports whether the installment is recomputed from the term
+func (d *Debt) IsAmortized() bool {
+	return d.Type == DebtTypeFixedAmortized || d.Type == DebtTypeFloatAmortized
+}
+
+// IsFloating reports whether the interest rate may change over time
+func (d *Debt) IsFloating() bool {
+	return d.Type == DebtTypeFloat || d.Type == DebtTypeFloatAmortized
+}
+
+// DebtRate represents a scheduled rate change of a floating debt
+// swagger:model
+type DebtRate struct {
+	ID        int64     `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+	DebtID    int64     `json:"debt_id"`
+
+	Type          string         `json:"type" gorm:"type:varchar(10);default:RATE"` // RATE, INDEX
+	EffectiveDate datatypes.Date `json:"effective_date"`
+
+	AnnualInterest float64  `json:"annual_interest"` // * RATE only
+	IndexRate      float64  `json:"index_rate"`      // * INDEX only
+	Margin         float64  `json:"margin"`          // * INDEX only
+	Cap            *float64 `json:"cap"`
+	Floor          *float64 `json:"floor"`
 }
+
+// Rate returns the annual interest applied from the effective date
+func (r *DebtRate) Rate() float64 {
+	rate := r.AnnualInterest
+	if r.Type == DebtRateTypeIndex {
+		rate = r.IndexRate + r.Margin
+	}
+
+	if r.Cap != nil && rate > *r.Cap {
+		rate = *r.Cap
+	}
+	if r.Floor != nil && rate < *r.Floor {
+		rate = *r.Floor
+	}
+
+	return rate
+}
+
+// Custom const
+const (
+	DebtTypeFixed          = "FIXED"
+	DebtTypeFixedAmortized = "FIXED_AMORTIZED"
+	DebtTypeFloat          = "FLOAT"
+	DebtTypeFloatAmortized = "FLOAT_AMORTIZED"
+
+	DebtStrategyAvalanche = "AVALANCHE" // * highest interest first
+	DebtStrategySnowball  = "SNOWBALL"  // * smallest balance first
+	DebtStrategyCustom    = "CUSTOM"    // * user defined priority
+
+	DebtTitleBehindDeadline       = "Debt %s Misses Its Deadline"
+	DebtDescriptionBehindDeadline = "Your current payment of %.2f$ will not clear your %s by its deadline, %.2f$ will be due at once. Pay at least %.2f$ per month to meet the deadline."
+
+	DebtRateTypeRate  = "RATE"
+	DebtRateTypeIndex = "INDEX"
+)
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ExchangeRate represents the rate of a currency from an effective date
+// swagger:model
+type ExchangeRate struct {
+	ID        int64     `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	Currency      string         `json:"currency" gorm:"type:varchar(3);uniqueIndex:idx_exchange_rates_version"` // * ISO 4217 code
+	Rate          float64        `json:"rate"`                                                                   // * units of the currency one US dollar buys
+	EffectiveDate datatypes.Date `json:"effective_date" gorm:"uniqueIndex:idx_exchange_rates_version"`
+}
+
+// CurrencyAmount holds an amount in a currency, the session currency when empty
+type CurrencyAmount struct {
+	Currency string
+	Amount   float64
+}
+
+// Custom const
+const (
+	CurrencyUSD = "USD" // * the currency every rate is quoted against, the session currency by default
+)
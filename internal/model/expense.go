@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"dullahan/internal/money"
+	"time"
+)
 
 // Expense represents expense model
 // swagger:model
@@ -10,9 +13,16 @@ type Expense struct {
 	UpdatedAt time.Time `json:"-"`
 	SessionID int64     `json:"session_id"`
 
-	Amount float64 `json:"amount"`
-	Name   string  `json:"name" gorm:"type:varchar(100)"`
-	Type   string  `json:"type" gorm:"type:varchar(15);default:ESSENTIAL"` // ESSENTIAL, NON_ESSENTIAL
+	Amount money.Decimal `json:"amount"`
+	Name   string        `json:"name" gorm:"type:varchar(100)"`
+	Type   string        `json:"type" gorm:"type:varchar(15);default:ESSENTIAL"` // ESSENTIAL, NON_ESSENTIAL
+
+	Currency        string  `json:"currency" gorm:"type:varchar(3)"` // * the session currency when empty
+	ExchangeRate    float64 `json:"exchange_rate" gorm:"-"`          // * to the session currency
+	ConvertedAmount float64 `json:"converted_amount" gorm:"-"`       // * in the session currency
+
+	Schedule
+	MonthlyAmount float64 `json:"monthly_amount" gorm:"-"` // * normalized monthly equivalent
 
 	Session *Session `json:"session,omitempty"`
 }
@@ -21,6 +31,11 @@ type Expense struct {
 const (
 	ExpenseTypeEssential    = "ESSENTIAL"
 	ExpenseTypeNonEssential = "NON_ESSENTIAL"
+
+	ExpenseTitleStarts       = "%s Expense Starts"
+	ExpenseTitleEnds         = "%s Expense Ends"
+	ExpenseDescriptionStarts = "Your %s starts, your monthly net flow goes down by %.2f$"
+	ExpenseDescriptionEnds   = "Your %s ends, your monthly net flow goes up by %.2f$ from the next month"
 )
 
 
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"dullahan/internal/money"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Goal represents a financial goal funded from the monthly surplus
+// swagger:model
+type Goal struct {
+	ID        int64     `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+	SessionID int64     `json:"session_id"`
+
+	Name         string         `json:"name" gorm:"type:varchar(100)"`
+	TargetAmount money.Decimal  `json:"target_amount"`
+	TargetDate   datatypes.Date `json:"target_date"`
+	Priority     int            `json:"priority" gorm:"default:0"` // * funding order, 0 means unset
+
+	ForecastCompletionDate string        `json:"forecast_completion_date" gorm:"type:varchar(50)"`
+	ForecastStatus         string        `json:"forecast_status" gorm:"type:varchar(10)"` // ON_TRACK, BEHIND
+	ForecastSavedAmount    money.Decimal `json:"forecast_saved_amount" gorm:"-"`          // * by the end of the forecast
+
+	Session *Session `json:"session,omitempty"`
+}
+
+// Custom const
+const (
+	GoalStatusOnTrack = "ON_TRACK"
+	GoalStatusBehind  = "BEHIND"
+
+	GoalTitleReached       = "Goal %s Reached"
+	GoalTitleBehind        = "Goal %s Behind Schedule"
+	GoalDescriptionReached = "You have saved the %.2f$ of your %s. Time to make it happen!"
+	GoalDescriptionBehind  = "Your %s will not be funded by its target date with your current surplus, %.2f$ of %.2f$ saved by the end of the forecast. Consider moving the date, raising its priority or saving more each month."
+)
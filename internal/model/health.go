@@ -0,0 +1,47 @@
+package model
+
+import "gorm.io/datatypes"
+
+// HealthScore represents the financial health score of a session on a day
+// swagger:model
+type HealthScore struct {
+	Base
+	SessionID  int64          `json:"-" gorm:"uniqueIndex:idx_health_scores_day"`
+	RecordedOn datatypes.Date `json:"recorded_on" gorm:"uniqueIndex:idx_health_scores_day"` // * one score a day, the latest one
+
+	Score                 int `json:"score"` // from 0 to 100
+	SavingsRateScore      int `json:"savings_rate_score"`
+	DebtToIncomeScore     int `json:"debt_to_income_score"`
+	EmergencyFundScore    int `json:"emergency_fund_score"`
+	HighInterestDebtScore int `json:"high_interest_debt_score"`
+
+	Components []*HealthComponent `json:"components,omitempty" gorm:"-"` // * highest impact first
+}
+
+// HealthComponent represents a sub-score of the health score
+// swagger:model
+type HealthComponent struct {
+	Name        string  `json:"name"`   // SAVINGS_RATE, DEBT_TO_INCOME, EMERGENCY_FUND, HIGH_INTEREST_DEBT
+	Value       float64 `json:"value"`  // * in months for EMERGENCY_FUND, in percent otherwise
+	Score       int     `json:"score"`  // from 0 to 100
+	Weight      float64 `json:"weight"` // * in percent of the health score
+	Impact      float64 `json:"impact"` // * points the health score gains when this component scores full points
+	Explanation string  `json:"explanation"`
+}
+
+// Custom const
+const (
+	HealthComponentSavingsRate      = "SAVINGS_RATE"
+	HealthComponentDebtToIncome     = "DEBT_TO_INCOME"
+	HealthComponentEmergencyFund    = "EMERGENCY_FUND"
+	HealthComponentHighInterestDebt = "HIGH_INTEREST_DEBT"
+
+	HealthExplanationSavingsRate      = "You keep %.2f%% of your income after expenses and debt payments, %.0f%% or more scores full points."
+	HealthExplanationNoIncome         = "You have no income to save from."
+	HealthExplanationDebtToIncome     = "Debt payments take %.2f%% of your gross income, %.0f%% or less scores full points and %.0f%% or more scores none."
+	HealthExplanationNoDebtPayment    = "You have no debt payments."
+	HealthExplanationEmergencyFund    = "Your balance covers %.2f months of essential expenses, %.0f months or more scores full points."
+	HealthExplanationNoEssential      = "You have no essential expenses to cover."
+	HealthExplanationHighInterestDebt = "%.2f%% of your debt charges %.0f%% or more annual interest, none scores full points."
+	HealthExplanationNoDebt           = "You have no debt."
+)
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"dullahan/internal/money"
+	"time"
+)
 
 // Income represents income model
 // swagger:model
@@ -10,9 +13,31 @@ type Income struct {
 	UpdatedAt time.Time `json:"-"`
 	SessionID int64     `json:"-"`
 
-	Amount float64 `json:"amount"`
-	Name   string  `json:"name" gorm:"type:varchar(100)"`
-	Type   string  `json:"type" gorm:"type:varchar(10);default:MONTHLY"` // MONTHLY, PASSIVE
+	Amount  money.Decimal `json:"amount"`
+	Name    string        `json:"name" gorm:"type:varchar(100)"`
+	Type    string        `json:"type" gorm:"type:varchar(10);default:MONTHLY"` // MONTHLY, PASSIVE
+	IsGross bool          `json:"is_gross"`                                     // * taxed by the rules of the session when true
+
+	Currency        string  `json:"currency" gorm:"type:varchar(3)"` // * the session currency when empty
+	ExchangeRate    float64 `json:"exchange_rate" gorm:"-"`          // * to the session currency
+	ConvertedAmount float64 `json:"converted_amount" gorm:"-"`       // * in the session currency
+
+	Schedule
+	MonthlyAmount float64 `json:"monthly_amount" gorm:"-"` // * normalized monthly equivalent
+
+	AnnualRaiseRate float64 `json:"annual_raise_rate"` // in percent
+	RaiseMonth      int     `json:"raise_month"`       // 1 to 12, the month the income was added when 0
 
 	Session *Session `json:"session,omitempty"`
 }
+
+// Custom const
+const (
+	IncomeTypeMonthly = "MONTHLY"
+	IncomeTypePassive = "PASSIVE"
+
+	IncomeTitleStarts       = "Income %s Starts"
+	IncomeTitleEnds         = "Income %s Ends"
+	IncomeDescriptionStarts = "Your %s starts, your monthly net flow goes up by %.2f$"
+	IncomeDescriptionEnds   = "Your %s ends, your monthly net flow goes down by %.2f$ from the next month"
+)
@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/datatypes"
 )
 
 // Base contains common fields for all models
@@ -23,3 +25,92 @@ type BaseWithoutID struct {
 	// The latest time that record is updated
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// Schedule contains how often and over which months an amount is received or paid
+type Schedule struct {
+	Frequency  string          `json:"frequency" gorm:"type:varchar(15);default:MONTHLY"` // WEEKLY, BIWEEKLY, SEMI_MONTHLY, MONTHLY, QUARTERLY, ANNUAL, ONE_TIME
+	AnchorDate *datatypes.Date `json:"anchor_date"`                                       // * a date it occurs on, the creation date when empty
+	StartDate  *datatypes.Date `json:"start_date"`                                        // * the first month it counts, already started when empty
+	EndDate    *datatypes.Date `json:"end_date"`                                          // * the last month it counts, never ends when empty
+}
+
+// NewSchedule returns the schedule of a new item, monthly by default
+func NewSchedule(frequency string, anchorDate *time.Time) Schedule {
+	schedule := Schedule{Frequency: frequency}
+	if frequency == "" {
+		schedule.Frequency = FrequencyMonthly
+	}
+	if anchorDate != nil {
+		date := datatypes.Date(*anchorDate)
+		schedule.AnchorDate = &date
+	}
+
+	return schedule
+}
+
+// SetPeriod sets the given start and end dates
+func (s *Schedule) SetPeriod(startDate, endDate *time.Time) {
+	if startDate != nil {
+		date := datatypes.Date(*startDate)
+		s.StartDate = &date
+	}
+	if endDate != nil {
+		date := datatypes.Date(*endDate)
+		s.EndDate = &date
+	}
+}
+
+// IsValidPeriod reports whether the schedule does not end before it starts
+func (s Schedule) IsValidPeriod() bool {
+	return s.StartDate == nil || s.EndDate == nil || !time.Time(*s.EndDate).Before(time.Time(*s.StartDate))
+}
+
+// IsBounded reports whether the schedule has a start or an end date
+func (s Schedule) IsBounded() bool {
+	return s.StartDate != nil || s.EndDate != nil
+}
+
+// MonthlyFactor returns how many times the amount occurs in an average month, zero when once only
+func (s Schedule) MonthlyFactor() float64 {
+	switch s.Frequency {
+	case FrequencyWeekly:
+		return 52.0 / 12
+	case FrequencyBiweekly:
+		return 26.0 / 12
+	case FrequencySemiMonthly:
+		return 2
+	case FrequencyQuarterly:
+		return 1.0 / 3
+	case FrequencyAnnual:
+		return 1.0 / 12
+	case FrequencyOneTime:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// MonthlyAmountSQL normalizes the amount of a row to its monthly equivalent, same as Schedule.MonthlyFactor
+const MonthlyAmountSQL = `CASE frequency
+	WHEN 'WEEKLY' THEN amount * 52 / 12
+	WHEN 'BIWEEKLY' THEN amount * 26 / 12
+	WHEN 'SEMI_MONTHLY' THEN amount * 2
+	WHEN 'QUARTERLY' THEN amount / 3
+	WHEN 'ANNUAL' THEN amount / 12
+	WHEN 'ONE_TIME' THEN 0
+	ELSE amount END`
+
+// ActiveSQL keeps the rows counting in the current month
+const ActiveSQL = `(start_date IS NULL OR start_date < date_trunc('month', CURRENT_DATE) + INTERVAL '1 month')
+	AND (end_date IS NULL OR end_date >= date_trunc('month', CURRENT_DATE))`
+
+// Custom const
+const (
+	FrequencyWeekly      = "WEEKLY"
+	FrequencyBiweekly    = "BIWEEKLY"
+	FrequencySemiMonthly = "SEMI_MONTHLY" // * the 1st and the 15th
+	FrequencyMonthly     = "MONTHLY"
+	FrequencyQuarterly   = "QUARTERLY"
+	FrequencyAnnual      = "ANNUAL"
+	FrequencyOneTime     = "ONE_TIME"
+)
@@ -12,11 +12,14 @@ const (
 
 // RBAC objects
 const (
-	ObjectAny     = "*"
-	ObjectSession = "session"
-	ObjectIncome  = "income"
-	ObjectExpense = "expense"
-	ObjectDebt    = "debt"
+	ObjectAny          = "*"
+	ObjectSession      = "session"
+	ObjectIncome       = "income"
+	ObjectExpense      = "expense"
+	ObjectDebt         = "debt"
+	ObjectGoal         = "goal"
+	ObjectScenario     = "scenario"
+	ObjectExchangeRate = "exchange_rate"
 )
 
 // RBAC actions
@@ -0,0 +1,13 @@
+package model
+
+import "gorm.io/datatypes"
+
+// Scenario represents a named set of what-if overrides of a session
+// swagger:model
+type Scenario struct {
+	Base
+	SessionID int64 `json:"-" gorm:"index"`
+
+	Name      string         `json:"name" gorm:"type:varchar(100)"`
+	Overrides datatypes.JSON `json:"overrides"` // * incomes, expenses, debts and parameters to add, modify or remove
+}
@@ -0,0 +1,42 @@
+package model
+
+// SensitivityDriver represents how the milestones shift when a forecast input moves one step each way
+// swagger:model
+type SensitivityDriver struct {
+	Driver string  `json:"driver"` // INCOME, EXPENSE, DEBT_PAYMENT, BALANCE, RETURN_RATE
+	ID     int64   `json:"id"`     // * of the income, expense or debt, 0 otherwise
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"` // * before the step, monthly for incomes and expenses, the annual return in percent for RETURN_RATE
+
+	Up     *SensitivityShift `json:"up"`
+	Down   *SensitivityShift `json:"down"`
+	Impact int               `json:"impact"` // * months the milestones move in total, both ways
+}
+
+// SensitivityShift represents the milestones once the input moved one step in a direction
+// swagger:model
+type SensitivityShift struct {
+	Value      float64           `json:"value"`
+	Milestones []*MilestoneShift `json:"milestones"`
+}
+
+// MilestoneShift represents the date of a milestone against the baseline forecast
+// swagger:model
+type MilestoneShift struct {
+	Milestone  string `json:"milestone"`
+	Baseline   string `json:"baseline"`
+	Date       string `json:"date"`
+	MonthsDiff *int   `json:"months_diff"` // * later than the baseline when positive, null when either is never reached
+}
+
+// Custom const
+const (
+	SensitivityDriverIncome      = "INCOME"
+	SensitivityDriverExpense     = "EXPENSE"
+	SensitivityDriverDebtPayment = "DEBT_PAYMENT"
+	SensitivityDriverBalance     = "BALANCE"
+	SensitivityDriverReturnRate  = "RETURN_RATE"
+
+	SensitivityNameBalance    = "Current Balance"
+	SensitivityNameReturnRate = "Expected Annual Return"
+)
@@ -1,8 +1,10 @@
 package model
 
 import (
+	"dullahan/internal/money"
 	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -17,26 +19,50 @@ type Session struct {
 	RefreshToken string     `json:"-" gorm:"type:varchar(100);unique_index"`
 	LastLogin    *time.Time `json:"last_login"`
 
-	TotalAllIncome           float64 `json:"total_all_income"`
-	TotalAllExpense          float64 `json:"total_all_expense"`
-	TotalMonthlyPaymentDebt  float64 `json:"total_monthly_payment_debt"`
-	TotalEssentialExpense    float64 `json:"total_essential_expense"`
-	TotalNonEssentialExpense float64 `json:"total_non_essential_expense"`
-	MonthlyNetFlow           float64 `json:"monthly_net_flow"` // important
+	TotalAllIncome           money.Decimal `json:"total_all_income"`
+	TotalPassiveIncome       money.Decimal `json:"total_passive_income"`
+	TotalGrossIncome         money.Decimal `json:"total_gross_income"`
+	TotalIncomeTax           money.Decimal `json:"total_income_tax"`
+	EffectiveTaxRate         float64       `json:"effective_tax_rate"` // in percent of the gross incomes
+	TotalAllExpense          money.Decimal `json:"total_all_expense"`
+	TotalMonthlyPaymentDebt  money.Decimal `json:"total_monthly_payment_debt"`
+	TotalEssentialExpense    money.Decimal `json:"total_essential_expense"`
+	TotalNonEssentialExpense money.Decimal `json:"total_non_essential_expense"`
+	MonthlyNetFlow           money.Decimal `json:"monthly_net_flow"` // important
 
-	CurrentBalance float64 `json:"current_balance"`
+	CurrentBalance money.Decimal `json:"current_balance"`
+	DebtStrategy   string        `json:"debt_strategy" gorm:"type:varchar(10);default:AVALANCHE"` // AVALANCHE, SNOWBALL, CUSTOM
+	Currency       string        `json:"currency" gorm:"type:varchar(3);default:USD"`             // * totals and forecasts are in this currency
 
-	ActualEmergencyFund   float64 `json:"actual_emergency_fund"`
-	ExpectedEmergencyFund float64 `json:"expected_emergency_fund"`
+	RiskProfile          string  `json:"risk_profile" gorm:"type:varchar(20);default:BALANCED"` // CONSERVATIVE, BALANCED, AGGRESSIVE
+	ExpectedAnnualReturn float64 `json:"expected_annual_return" gorm:"default:12.23"`           // in percent
+	ExpectedVolatility   float64 `json:"expected_volatility" gorm:"default:15"`                 // in percent
+	InflationRate        float64 `json:"inflation_rate"`                                        // in percent, applied to expenses
 
-	ActualRainydayFund   float64 `json:"actual_rainyday_fund"`
-	ExpectedRainydayFund float64 `json:"expected_rainyday_fund"`
+	BudgetPolicy BudgetPolicy `json:"budget_policy" gorm:"embedded;embeddedPrefix:policy_"`
 
-	ActualFunFund   float64 `json:"actual_fun_fund"`
-	ExpectedFunFund float64 `json:"expected_fun_fund"`
+	TaxRegion      string       `json:"tax_region" gorm:"type:varchar(10)"`       // * gross incomes are not taxed when empty
+	TaxRuleVersion string       `json:"tax_rule_version" gorm:"type:varchar(20)"` // * the latest one when empty
+	Tax            *TaxLineItem `json:"tax,omitempty" gorm:"-"`
 
-	Investment     float64 `json:"investment"`
-	RetirementPlan float64 `json:"retirement_plan"`
+	TaxExchangeRate float64 `json:"tax_exchange_rate" gorm:"-"` // * of the tax rule currency to the session currency
+
+	BirthDate          *datatypes.Date `json:"birth_date"`
+	RetirementAge      int             `json:"retirement_age" gorm:"default:65"`
+	LifeExpectancy     int             `json:"life_expectancy" gorm:"default:90"`
+	SafeWithdrawalRate float64         `json:"safe_withdrawal_rate" gorm:"default:4"` // in percent
+
+	ActualEmergencyFund   money.Decimal `json:"actual_emergency_fund"`
+	ExpectedEmergencyFund money.Decimal `json:"expected_emergency_fund"`
+
+	ActualRainydayFund   money.Decimal `json:"actual_rainyday_fund"`
+	ExpectedRainydayFund money.Decimal `json:"expected_rainyday_fund"`
+
+	ActualFunFund   money.Decimal `json:"actual_fun_fund"`
+	ExpectedFunFund money.Decimal `json:"expected_fun_fund"`
+
+	Investment     money.Decimal `json:"investment"`
+	RetirementPlan money.Decimal `json:"retirement_plan"`
 
 	IsAchivedEmergencyFund  bool `json:"is_achived_emergency_fund"`
 	IsAchivedRainydayFund   bool `json:"is_achived_rainyday_fund"`
@@ -49,6 +75,12 @@ type Session struct {
 	ForecastFinancialFreedomDate      string `json:"forecast_financial_freedom_date" gorm:"type:varchar(50)"`
 	ForecastMillionaireDate           string `json:"forecast_millionaire_date" gorm:"type:varchar(50)"`
 	ForecastBankrupt                  string `json:"forecast_bankrupt" gorm:"type:varchar(50)"`
+	ForecastRetirementDate            string `json:"forecast_retirement_date" gorm:"type:varchar(50)"`
+	ForecastMoneyRunsOutDate          string `json:"forecast_money_runs_out_date" gorm:"type:varchar(50)"`
+	ForecastMoneyRunsOutAge           int    `json:"forecast_money_runs_out_age"` // * 0 when the savings last
+
+	Retirement *RetirementProjection `json:"retirement,omitempty" gorm:"-"`
+	Health     *HealthScore          `json:"health,omitempty" gorm:"-"`
 
 	Status      string `json:"status" gorm:"type:varchar(10)"`
 	FullStatus  string `json:"full_status" gorm:"-"`
@@ -59,6 +91,7 @@ type Session struct {
 	Incomes  []*Income  `json:"incomes,omitempty"`
 	Expenses []*Expense `json:"expenses,omitempty"`
 	Debts    []*Debt    `json:"debts,omitempty"`
+	Goals    []*Goal    `json:"goals,omitempty"`
 
 	// DataLinecharts []*LineChart `json:"data_linecharts,omitempty" gorm:"-"`
 	// DataTimelines  []*Timeline  `json:"data_timelines,omitempty" gorm:"-"`
@@ -73,6 +106,98 @@ type LineChart struct {
 	Debt  float64 `json:"debt"`
 }
 
+// BudgetPolicy represents the budget rules a session is calculated with
+// swagger:model
+type BudgetPolicy struct {
+	EmergencyFundMonths float64 `json:"emergency_fund_months" gorm:"default:6"`                   // * of essential expenses
+	RainydayFundMonths  float64 `json:"rainyday_fund_months" gorm:"default:3"`                    // * of essential expenses
+	FunFundRate         float64 `json:"fun_fund_rate" gorm:"default:20"`                          // * percent of the monthly net flow
+	FunFundCap          float64 `json:"fun_fund_cap" gorm:"default:0"`                            // * most set aside each month, no cap when zero
+	FunFundMode         string  `json:"fun_fund_mode" gorm:"type:varchar(15);default:SPEND"`      // SPEND, ROLLOVER
+	RetirementPlanYears float64 `json:"retirement_plan_years" gorm:"default:10"`                  // * of essential expenses
+	PaycheckCeil        float64 `json:"paycheck_ceil" gorm:"default:200"`                         // * net flow below is paycheck to paycheck
+	GoodFlexibilityRate float64 `json:"good_flexibility_rate" gorm:"default:1"`                   // * net flow above this many essential expenses is good flexibility
+	FreedomCovers       string  `json:"freedom_covers" gorm:"type:varchar(15);default:ESSENTIAL"` // ESSENTIAL, TOTAL expenses
+}
+
+// RetirementProjection represents the savings of a session through retirement
+// swagger:model
+type RetirementProjection struct {
+	RetirementDate             string        `json:"retirement_date"`
+	RetirementAge              int           `json:"retirement_age"`
+	LifeExpectancy             int           `json:"life_expectancy"`
+	SafeWithdrawalRate         float64       `json:"safe_withdrawal_rate"`
+	SavingsAtRetirement        float64       `json:"savings_at_retirement"`
+	RequiredSavings            float64       `json:"required_savings"` // * to cover the expenses at the safe withdrawal rate
+	SafeMonthlyWithdrawal      float64       `json:"safe_monthly_withdrawal"`
+	MonthlyExpenseAtRetirement float64       `json:"monthly_expense_at_retirement"`
+	MonthlyPassiveIncome       float64       `json:"monthly_passive_income"`
+	IsSustainable              bool          `json:"is_sustainable"` // * the savings last until the life expectancy
+	MoneyRunsOutDate           string        `json:"money_runs_out_date,omitempty"`
+	MoneyRunsOutAge            int           `json:"money_runs_out_age,omitempty"`
+	Balances                   []*AgeBalance `json:"balances"` // * yearly
+}
+
+// AgeBalance represents the net worth at an age
+// swagger:model
+type AgeBalance struct {
+	Age     int     `json:"age"`
+	Date    string  `json:"date"`
+	Balance float64 `json:"balance"`
+}
+
+// TaxLineItem represents the monthly taxes withheld from the gross incomes
+// swagger:model
+type TaxLineItem struct {
+	Region              string             `json:"region"`
+	Version             string             `json:"version"`
+	GrossIncome         float64            `json:"gross_income"`
+	Deduction           float64            `json:"deduction"`
+	TaxableIncome       float64            `json:"taxable_income"`
+	IncomeTax           float64            `json:"income_tax"`
+	SocialContributions []*TaxContribution `json:"social_contributions"`
+	TotalTax            float64            `json:"total_tax"`
+	NetIncome           float64            `json:"net_income"`
+	EffectiveRate       float64            `json:"effective_rate"` // * percent of the gross income
+}
+
+// TaxContribution represents a monthly social contribution
+// swagger:model
+type TaxContribution struct {
+	Name   string  `json:"name"`
+	Rate   float64 `json:"rate"`
+	Amount float64 `json:"amount"`
+}
+
+// ReturnAssumptions represents the investment return a forecast is calculated with
+// swagger:model
+type ReturnAssumptions struct {
+	RiskProfile          string  `json:"risk_profile"`
+	ExpectedAnnualReturn float64 `json:"expected_annual_return"`
+	ExpectedVolatility   float64 `json:"expected_volatility"`
+	MonthlyReturnRate    float64 `json:"monthly_return_rate"`
+	InflationRate        float64 `json:"inflation_rate"`
+	InflationAdjusted    bool    `json:"inflation_adjusted"`
+}
+
+// AssetBand represents the spread of the assets over randomized paths in a period
+// swagger:model
+type AssetBand struct {
+	Key string  `json:"key"`
+	P10 float64 `json:"p10"`
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+}
+
+// MilestoneProbability represents the chance of reaching a milestone by a date
+// swagger:model
+type MilestoneProbability struct {
+	Milestone   string  `json:"milestone"` // EMERGENCY_FUND, FINANCIAL_FREEDOM, MILLIONAIRE
+	By          string  `json:"by"`
+	Probability float64 `json:"probability"` // from 0 to 1
+	MedianDate  string  `json:"median_date"` // empty when reached in less than half of the paths
+}
+
 // Timeline represents the data timeline
 // swagger:model
 type Timeline struct {
@@ -80,6 +205,7 @@ type Timeline struct {
 	Date        string    `json:"date"`
 	Datetime    time.Time `json:"-"`
 	Description string    `json:"description"`
+	Age         int       `json:"age,omitempty"`
 }
 
 // "total_all_income":           totalIncome,
@@ -106,8 +232,15 @@ type DataNode struct {
 
 	CurrentAsset              float64 `json:"current_asset"`
 	TotalAllIncome            float64 `json:"total_all_income"`
+	TotalPassiveIncome        float64 `json:"total_passive_income"`
+	TotalGrossIncome          float64 `json:"total_gross_income"`
+	TotalIncomeTax            float64 `json:"total_income_tax"`
+	EffectiveTaxRate          float64 `json:"effective_tax_rate"`
 	TotalAllExpense           float64 `json:"total_all_expense"`
+	TotalEssentialExpense     float64 `json:"total_essential_expense"`
+	TotalNonEssentialExpense  float64 `json:"total_non_essential_expense"`
 	TotalMonthlyPaymentDebt   float64 `json:"total_monthly_payment_debt"`
+	TotalRemainingDebt        float64 `json:"total_remaining_debt"`
 	MonthlyNetFlow            float64 `json:"monthly_net_flow"`
 	Status                    string  `json:"status"`
 	Descrtiption              string  `json:"description"`
@@ -131,11 +264,13 @@ type DataNode struct {
 type DataDebtNode struct {
 	SessionID int64  `json:"session_id"`
 	NodeName  string `json:"node_name"`
+	Month     int64  `json:"month"` // * months since the start of the forecast
 	DebtID    int64  `json:"debt_id"`
 	Index     int    `json:"index"`
 
 	RemainingAmount float64 `json:"remaining_amount"`
 	MonthlyPayment  float64 `json:"monthly_payment"`
+	InterestPaid    float64 `json:"interest_paid"`
 
 	IsEligiblePaidOff bool `json:"is_eligible_paid_off"`
 	IsPaidOff         bool `json:"is_paid_off"`
@@ -167,6 +302,16 @@ const (
 	SessionStatusDescriptionLFF     = "You have some extra money left over at the end of the month, but not enough to save or invest. This can make it difficult for you to respond to unexpected expenses or changes in income."
 	SessionStatusDescriptionGFF     = "Your monthly net income exceeds your essential expenses. This provides you with extra money at the end of each month that you can save or invest. This extra financial cushion can help you respond to unexpected expenses or changes in income. Consider saving toward the Emergency and Rainy Day Fund if you haven't done so."
 
+	SessionRiskProfileConservative = "CONSERVATIVE"
+	SessionRiskProfileBalanced     = "BALANCED"
+	SessionRiskProfileAggressive   = "AGGRESSIVE"
+
+	FunFundModeSpend    = "SPEND"    // * set aside then spent each month
+	FunFundModeRollover = "ROLLOVER" // * unspent fun fund adds up month to month
+
+	FreedomCoversEssential = "ESSENTIAL" // * passive incomes and yield cover the essential expenses
+	FreedomCoversTotal     = "TOTAL"     // * passive incomes and yield cover every expense
+
 	DatasetTypeAsset = "asset"
 	DatasetTypeDebt  = "debt"
 
@@ -176,12 +321,16 @@ const (
 	SessionTitleForecastFinancialFreedom      = "Financial Freedom"
 	SessionTitleForecastBankrupt              = "Watch out, your pocket is empty!!!"
 	SessionTitleForecastMillionaire           = "Millionaire!!!"
+	SessionTitleForecastRetirement            = "Retirement"
+	SessionTitleForecastMoneyRunsOut          = "Retirement Savings Run Out"
 
 	SessionDescriptionForecastEmergencyBudgetFilled = "You just achieved your Emergency Budget, now you will be feeling at ease in case any emergency happened"
 	SessionDescriptionForecastRainydayBudgetFilled  = "Your Rainy Day budget achieved, well done, your Finance Journey will start getting easier from this point"
 	SessionDescriptionForecastStartInvesting        = "You can now start Investing, whether by deposit to the bank, buying ETF funds. You should start doing research and let the money work for you. Averagely, investing in safe option can give you around 10%-11% annual interest rate. From this point forward, we will accumulate your asset as if you are investing to let you see the power of compound interest. However, please make sure you have prepared your knowledge in investing fields first before considering it into action."
 	SessionDescriptionForecastFinancialFreedom      = "You are now Financially Free, you can now do almost whatever you want, maybe finding a job you really like, travel the world, or enjoy life a little. This doesn't mean the end of your financial journey though, it’s just improve your life quality from here by giving you more options to choose. Never stop trying to keep a good financial performance."
 	SessionDescriptionForecastBankrupt              = "Oops, looks like you are running on a budget deficit which causes your budget to reach. We get it, life is tough, you have bills to pay, people to take care and not to mention time for yourself. But the situation aint great, considering cutting some of the non-essential expenses, and improving your income. Don't worry you are not alone and this is solvable, hand in there."
+	SessionDescriptionForecastRetirement            = "You reach your retirement age with %.2f$ saved. From here your savings and passive incomes pay for your %.2f$ of monthly expenses."
+	SessionDescriptionForecastMoneyRunsOut          = "Your savings run out at the age of %d, before your life expectancy. Consider saving more, retiring later or lowering your expenses in retirement."
 	SessionDescriptionForecastMillionaire           = "You are now a millionaire. It doesn't matter if your journey is different from the others, you deserve a well-big congratulation here. The first million is always hard to make but you made it at this point, you will be fine from here. However, if this is the point in the far future, maybe you can start improving your income? Reduce your Expense? A dollar more in income or less in expense may go a longer way than you think."
 )
 
@@ -0,0 +1,318 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact amount of money, stored as ten-thousandths of the unit
+// swagger:type number
+type Decimal int64
+
+// Const
+const (
+	Scale = 4 // * decimal places a Decimal keeps
+	Cents = 2 // * decimal places amounts are shown with
+
+	RoundHalfUp   = "HALF_UP"   // * ties away from zero
+	RoundHalfEven = "HALF_EVEN" // * ties to the even digit, banker's rounding
+
+	Max Decimal = 999_999_999_999_999_999 // * the largest amount a numeric(18,4) column holds
+)
+
+// Errors
+var (
+	ErrInvalidDecimal      = errors.New("invalid decimal")
+	ErrInvalidRoundingMode = errors.New("invalid rounding mode")
+	ErrOutOfRange          = errors.New("decimal out of range")
+)
+
+var roundingMode = RoundHalfUp
+
+// SetRoundingMode sets how ties are rounded, half up when empty
+func SetRoundingMode(mode string) error {
+	switch mode {
+	case "":
+		roundingMode = RoundHalfUp
+	case RoundHalfUp, RoundHalfEven:
+		roundingMode = mode
+	default:
+		return ErrInvalidRoundingMode
+	}
+
+	return nil
+}
+
+// RoundingMode returns how ties are rounded
+func RoundingMode() string {
+	return roundingMode
+}
+
+// FromFloat returns the decimal of the float like NewFromFloat, clamped to the range, 0 when not a number
+func FromFloat(f float64) Decimal {
+	d, _ := NewFromFloat(f)
+	return d
+}
+
+// NewFromFloat returns the decimal of the shortest representation of the float, so 0.1 stays 0.1.
+// Out of range floats return ErrOutOfRange with the decimal clamped to the range.
+func NewFromFloat(f float64) (Decimal, error) {
+	switch {
+	case math.IsNaN(f):
+		return 0, fmt.Errorf("%w: %v", ErrOutOfRange, f)
+	case f >= Max.Float64()+0.5/math.Pow10(Scale):
+		return Max, fmt.Errorf("%w: %v", ErrOutOfRange, f)
+	case f <= -Max.Float64()-0.5/math.Pow10(Scale):
+		return -Max, fmt.Errorf("%w: %v", ErrOutOfRange, f)
+	}
+
+	return Parse(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// Parse returns the decimal of a number string, extra decimal places are rounded
+func Parse(s string) (Decimal, error) {
+	units, err := parse(s, Scale)
+	if err == nil && (units > int64(Max) || units < -int64(Max)) {
+		return 0, fmt.Errorf("%w: %q", ErrOutOfRange, s)
+	}
+
+	return Decimal(units), err
+}
+
+// RoundFloat rounds the float to the given decimal places with the rounding mode
+func RoundFloat(f float64, places int) float64 {
+	pow := math.Pow(10, float64(places))
+	scaled := f * pow
+	floor := math.Floor(scaled)
+
+	// * far enough from a tie the float math is exact enough, otherwise the digits decide
+	if diff := scaled - floor; math.Abs(diff-0.5) > 1e-9*math.Max(1, math.Abs(scaled)) {
+		if diff > 0.5 {
+			floor++
+		}
+		return floor / pow
+	}
+
+	units, err := parse(strconv.FormatFloat(f, 'f', -1, 64), places)
+	if err != nil {
+		return f
+	}
+
+	return float64(units) / pow
+}
+
+// Add returns d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	return d + o
+}
+
+// Sub returns d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	return d - o
+}
+
+// Mul returns d multiplied by the shortest representation of the factor, rounded to the scale
+// with the rounding mode. An out of range product returns ErrOutOfRange with the product clamped
+// to the range.
+func (d Decimal) Mul(factor float64) (Decimal, error) {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return 0, fmt.Errorf("%w: %v", ErrOutOfRange, factor)
+	}
+
+	product, _ := new(big.Rat).SetString(strconv.FormatFloat(factor, 'f', -1, 64))
+	product.Mul(product, new(big.Rat).SetInt64(int64(d)))
+
+	return fromRat(product)
+}
+
+// Div returns d divided by the divisor, rounded to the scale with the rounding mode, the divisor must not be zero
+func (d Decimal) Div(divisor int64) Decimal {
+	q, _ := fromRat(new(big.Rat).SetFrac(big.NewInt(int64(d)), big.NewInt(divisor)))
+	return q
+}
+
+// Round returns d rounded to the given decimal places with the rounding mode
+func (d Decimal) Round(places int) Decimal {
+	if places >= Scale {
+		return d
+	}
+
+	step := int64(math.Pow10(Scale - places))
+	q, r := int64(d)/step, int64(d)%step
+	if r < 0 {
+		r = -r
+	}
+
+	if r*2 > step || r*2 == step && (roundingMode == RoundHalfUp || q%2 != 0) {
+		if d < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+
+	return Decimal(q * step)
+}
+
+// Float64 returns the closest float of d
+func (d Decimal) Float64() float64 {
+	return float64(d) / math.Pow10(Scale)
+}
+
+// String returns d without trailing zeros, the same digits a float prints
+func (d Decimal) String() string {
+	sign := ""
+	units := int64(d)
+	if units < 0 {
+		sign, units = "-", -units
+	}
+
+	unit := int64(math.Pow10(Scale))
+	s := sign + strconv.FormatInt(units/unit, 10)
+	if frac := units % unit; frac != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%0*d", Scale, frac), "0")
+	}
+
+	return s
+}
+
+// MarshalJSON writes d as a plain JSON number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads d from a JSON number or string
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	v, err := Parse(strings.Trim(s, `"`))
+	if err != nil {
+		return err
+	}
+	*d = v
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface
+func (d *Decimal) Scan(value interface{}) (err error) {
+	switch v := value.(type) {
+	case nil:
+		*d = 0
+	case float64:
+		*d, err = NewFromFloat(v)
+	case int64:
+		unit := int64(math.Pow10(Scale))
+		if v > int64(Max)/unit || v < -int64(Max)/unit {
+			return fmt.Errorf("%w: %d", ErrOutOfRange, v)
+		}
+		*d = Decimal(v * unit)
+	case []byte:
+		*d, err = Parse(string(v))
+	case string:
+		*d, err = Parse(v)
+	default:
+		err = fmt.Errorf("%w: %T", ErrInvalidDecimal, value)
+	}
+
+	return err
+}
+
+// Value implements the driver.Valuer interface
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// GormDataType returns the column type
+func (Decimal) GormDataType() string {
+	return "numeric(18,4)"
+}
+
+// parse returns the number string in units of the given decimal places, rounded with the rounding mode.
+// A single leading sign is accepted.
+func parse(s string, places int) (int64, error) {
+	s = strings.TrimSpace(s)
+
+	digits, neg := s, false
+	switch {
+	case strings.HasPrefix(digits, "-"):
+		digits, neg = digits[1:], true
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	rest := ""
+	if len(fracPart) > places {
+		fracPart, rest = fracPart[:places], fracPart[places:]
+	}
+	fracPart += strings.Repeat("0", places-len(fracPart))
+
+	// * only digits are left, so the parse fails on overflow alone
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrOutOfRange, s)
+	}
+
+	if roundsAway(units, rest) {
+		units++
+	}
+	if neg {
+		units = -units
+	}
+
+	return units, nil
+}
+
+// fromRat returns the units of the exact number rounded with the rounding mode, clamped to the range
+func fromRat(units *big.Rat) (Decimal, error) {
+	// * truncated units and what is left of them decide the rounding
+	q, r := new(big.Int).QuoRem(units.Num(), units.Denom(), new(big.Int))
+	r.Abs(r).Lsh(r, 1)
+	if c := r.Cmp(units.Denom()); c > 0 || c == 0 && (roundingMode == RoundHalfUp || q.Bit(0) != 0) {
+		q.Add(q, big.NewInt(int64(units.Sign())))
+	}
+
+	switch {
+	case q.Cmp(big.NewInt(int64(Max))) > 0:
+		return Max, fmt.Errorf("%w: %v", ErrOutOfRange, units.FloatString(0))
+	case q.Cmp(big.NewInt(int64(-Max))) < 0:
+		return -Max, fmt.Errorf("%w: %v", ErrOutOfRange, units.FloatString(0))
+	}
+
+	return Decimal(q.Int64()), nil
+}
+
+// roundsAway reports whether the dropped digits round the kept units away from zero
+func roundsAway(units int64, dropped string) bool {
+	if dropped == "" || dropped[0] < '5' {
+		return false
+	}
+	if dropped[0] > '5' || strings.TrimRight(dropped[1:], "0") != "" {
+		return true
+	}
+
+	return roundingMode == RoundHalfUp || units%2 != 0
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
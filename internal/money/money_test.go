@@ -0,0 +1,291 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+// withRoundingMode runs the test with the rounding mode, then puts the default back
+func withRoundingMode(t *testing.T, mode string) {
+	t.Helper()
+	if err := SetRoundingMode(mode); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetRoundingMode("") })
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Decimal
+		err  error
+	}{
+		{"0", 0, nil},
+		{"12", 120000, nil},
+		{"12.5", 125000, nil},
+		{".5", 5000, nil},
+		{"5.", 50000, nil},
+		{" 1.2345 ", 12345, nil},
+		{"+5", 50000, nil},
+		{"-5", -50000, nil},
+		{"-0.0001", -1, nil},
+		{"1.23456", 12346, nil},
+		{"-1.23456", -12346, nil},
+		{"99999999999999.9999", Max, nil},
+		{"-99999999999999.9999", -Max, nil},
+		{"", 0, ErrInvalidDecimal},
+		{".", 0, ErrInvalidDecimal},
+		{"-", 0, ErrInvalidDecimal},
+		{"--5", 0, ErrInvalidDecimal},
+		{"+-5", 0, ErrInvalidDecimal},
+		{"-+5", 0, ErrInvalidDecimal},
+		{"5-", 0, ErrInvalidDecimal},
+		{"1e3", 0, ErrInvalidDecimal},
+		{"1.2.3", 0, ErrInvalidDecimal},
+		{"100000000000000", 0, ErrOutOfRange},
+		{"99999999999999999999", 0, ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestParseTies(t *testing.T) {
+	tests := []struct {
+		in       string
+		halfUp   Decimal
+		halfEven Decimal
+	}{
+		{"0.00005", 1, 0},
+		{"0.00015", 2, 2},
+		{"0.00025", 3, 2},
+		{"0.000250001", 3, 3},
+		{"-0.00025", -3, -2},
+		{"-0.00035", -4, -4},
+	}
+
+	for _, mode := range []string{RoundHalfUp, RoundHalfEven} {
+		withRoundingMode(t, mode)
+		for _, tt := range tests {
+			want := tt.halfUp
+			if mode == RoundHalfEven {
+				want = tt.halfEven
+			}
+			if got, err := Parse(tt.in); err != nil || got != want {
+				t.Errorf("%s Parse(%q) = %v, %v, want %v", mode, tt.in, got, err, want)
+			}
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		in       Decimal
+		places   int
+		halfUp   Decimal
+		halfEven Decimal
+	}{
+		{12344, Cents, 12300, 12300},
+		{12351, Cents, 12400, 12400},
+		{12350, Cents, 12400, 12400},
+		{12250, Cents, 12300, 12200},
+		{-12250, Cents, -12300, -12200},
+		{-12350, Cents, -12400, -12400},
+		{-12349, Cents, -12300, -12300},
+		{25000, 0, 30000, 20000},
+		{-25000, 0, -30000, -20000},
+		{12345, Scale, 12345, 12345},
+	}
+
+	for _, mode := range []string{RoundHalfUp, RoundHalfEven} {
+		withRoundingMode(t, mode)
+		for _, tt := range tests {
+			want := tt.halfUp
+			if mode == RoundHalfEven {
+				want = tt.halfEven
+			}
+			if got := tt.in.Round(tt.places); got != want {
+				t.Errorf("%s %v.Round(%d) = %v, want %v", mode, tt.in, tt.places, got, want)
+			}
+		}
+	}
+}
+
+func TestRoundFloat(t *testing.T) {
+	tests := []struct {
+		in       float64
+		halfUp   float64
+		halfEven float64
+	}{
+		{1.234, 1.23, 1.23},
+		{1.236, 1.24, 1.24},
+		{1.005, 1.01, 1.00},
+		{1.015, 1.02, 1.02},
+		{1.125, 1.13, 1.12},
+		{2.675, 2.68, 2.68},
+		{-1.125, -1.13, -1.12},
+		{-1.236, -1.24, -1.24},
+		{0.1 + 0.2, 0.3, 0.3},
+	}
+
+	for _, mode := range []string{RoundHalfUp, RoundHalfEven} {
+		withRoundingMode(t, mode)
+		for _, tt := range tests {
+			want := tt.halfUp
+			if mode == RoundHalfEven {
+				want = tt.halfEven
+			}
+			if got := RoundFloat(tt.in, Cents); got != want {
+				t.Errorf("%s RoundFloat(%v) = %v, want %v", mode, tt.in, got, want)
+			}
+		}
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want Decimal
+		err  error
+	}{
+		{0.1, 1000, nil},
+		{-0.1, -1000, nil},
+		{1234.5678, 12345678, nil},
+		{1e14, Max, ErrOutOfRange},
+		{-1e14, -Max, ErrOutOfRange},
+		{1e20, Max, ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		got, err := NewFromFloat(tt.in)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("NewFromFloat(%v) = %v, %v, want %v, %v", tt.in, got, err, tt.want, tt.err)
+		}
+		if got := FromFloat(tt.in); got != tt.want {
+			t.Errorf("FromFloat(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMul(t *testing.T) {
+	if got, err := Decimal(1000000).Mul(0.04); err != nil || got != 40000 {
+		t.Errorf("Mul = %v, %v, want 4, nil", got, err)
+	}
+	if got, err := Max.Mul(25000); !errors.Is(err, ErrOutOfRange) || got != Max {
+		t.Errorf("Mul = %v, %v, want %v, %v", got, err, Max, ErrOutOfRange)
+	}
+	if got, err := Decimal(1000000).Mul(1.0 / 3); err != nil || got != 333333 {
+		t.Errorf("Mul = %v, %v, want 33.3333, nil", got, err)
+	}
+}
+
+func TestMulTies(t *testing.T) {
+	tests := []struct {
+		in       Decimal
+		factor   float64
+		halfUp   Decimal
+		halfEven Decimal
+	}{
+		{1, 0.5, 1, 0},
+		{3, 0.5, 2, 2},
+		{-1, 0.5, -1, 0},
+		{-3, 0.5, -2, -2},
+		{12345, 0.1, 1235, 1234},
+		{10000, 0.00005, 1, 0},
+	}
+
+	for _, mode := range []string{RoundHalfUp, RoundHalfEven} {
+		withRoundingMode(t, mode)
+		for _, tt := range tests {
+			want := tt.halfUp
+			if mode == RoundHalfEven {
+				want = tt.halfEven
+			}
+			if got, err := tt.in.Mul(tt.factor); err != nil || got != want {
+				t.Errorf("%s %v.Mul(%v) = %v, %v, want %v", mode, tt.in, tt.factor, got, err, want)
+			}
+		}
+	}
+}
+
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		in       Decimal
+		divisor  int64
+		halfUp   Decimal
+		halfEven Decimal
+	}{
+		{1000000, 3, 333333, 333333},
+		{2000000, 3, 666667, 666667},
+		{5, 2, 3, 2},
+		{-5, 2, -3, -2},
+		{7, 2, 4, 4},
+		{120000, 12, 10000, 10000},
+	}
+
+	for _, mode := range []string{RoundHalfUp, RoundHalfEven} {
+		withRoundingMode(t, mode)
+		for _, tt := range tests {
+			want := tt.halfUp
+			if mode == RoundHalfEven {
+				want = tt.halfEven
+			}
+			if got := tt.in.Div(tt.divisor); got != want {
+				t.Errorf("%s %v.Div(%d) = %v, want %v", mode, tt.in, tt.divisor, got, want)
+			}
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want Decimal
+		err  error
+	}{
+		{nil, 0, nil},
+		{"12.3456", 123456, nil},
+		{[]byte("-12.3456"), -123456, nil},
+		{"-0.5000", -5000, nil},
+		{int64(42), 420000, nil},
+		{int64(-42), -420000, nil},
+		{0.1, 1000, nil},
+		{-2.5, -25000, nil},
+		{int64(1e15), 0, ErrOutOfRange},
+		{1e15, Max, ErrOutOfRange},
+		{"1e3", 0, ErrInvalidDecimal},
+		{true, 0, ErrInvalidDecimal},
+	}
+
+	for _, tt := range tests {
+		var got Decimal
+		err := got.Scan(tt.in)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("Scan(%v) = %v, %v, want %v, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   Decimal
+		want string
+	}{
+		{0, "0"},
+		{10000, "1"},
+		{12500, "1.25"},
+		{-1, "-0.0001"},
+		{-123456, "-12.3456"},
+		{Max, "99999999999999.9999"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
@@ -22,10 +22,21 @@ func New(enableLog bool) *rbac.RBAC {
 	r.AddPolicy(model.RoleCustomer, model.ObjectExpense, model.ActionUpdate)
 	r.AddPolicy(model.RoleCustomer, model.ObjectExpense, model.ActionDelete)
 
+	r.AddPolicy(model.RoleCustomer, model.ObjectDebt, model.ActionView)
 	r.AddPolicy(model.RoleCustomer, model.ObjectDebt, model.ActionCreate)
 	r.AddPolicy(model.RoleCustomer, model.ObjectDebt, model.ActionUpdate)
 	r.AddPolicy(model.RoleCustomer, model.ObjectDebt, model.ActionDelete)
 
+	r.AddPolicy(model.RoleCustomer, model.ObjectGoal, model.ActionView)
+	r.AddPolicy(model.RoleCustomer, model.ObjectGoal, model.ActionCreate)
+	r.AddPolicy(model.RoleCustomer, model.ObjectGoal, model.ActionUpdate)
+	r.AddPolicy(model.RoleCustomer, model.ObjectGoal, model.ActionDelete)
+
+	r.AddPolicy(model.RoleCustomer, model.ObjectScenario, model.ActionView)
+	r.AddPolicy(model.RoleCustomer, model.ObjectScenario, model.ActionCreate)
+	r.AddPolicy(model.RoleCustomer, model.ObjectScenario, model.ActionUpdate)
+	r.AddPolicy(model.RoleCustomer, model.ObjectScenario, model.ActionDelete)
+
 	// Add permission for admin role
 	r.AddPolicy(model.RoleAdmin, model.ObjectAny, model.ActionAny)
 
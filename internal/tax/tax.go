@@ -0,0 +1,200 @@
+package tax
+
+import (
+	"dullahan/internal/model"
+	"dullahan/internal/money"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math"
+	"sort"
+)
+
+//go:embed rules
+var rulesFS embed.FS
+
+// ErrRuleNotFound is returned when no rule matches the region and version
+var ErrRuleNotFound = errors.New("tax rule not found")
+
+// Calculator returns the monthly tax line item of a monthly gross income
+type Calculator interface {
+	Calculate(monthlyGross float64) *model.TaxLineItem
+}
+
+// Rule holds the tax rules of a region for a version, amounts are annual
+type Rule struct {
+	Region              string          `json:"region"`
+	Version             string          `json:"version"`
+	Currency            string          `json:"currency"` // * the amounts are in this currency
+	Description         string          `json:"description"`
+	StandardDeduction   float64         `json:"standard_deduction"`
+	Brackets            []*Bracket      `json:"brackets"`
+	SocialContributions []*Contribution `json:"social_contributions"`
+}
+
+// Bracket holds the rate of the taxable income up to an amount, no limit when zero
+type Bracket struct {
+	UpTo float64 `json:"up_to"`
+	Rate float64 `json:"rate"` // * in percent
+}
+
+// Contribution holds a social contribution withheld from the gross income
+type Contribution struct {
+	Name       string  `json:"name"`
+	Rate       float64 `json:"rate"`       // * in percent
+	Cap        float64 `json:"cap"`        // * most of the gross income it applies to, no cap when zero
+	Deductible bool    `json:"deductible"` // * lowers the taxable income
+}
+
+// rules are the embedded rule files by region, oldest version first
+var rules = mustLoad()
+
+// Lookup returns the calculator of the region, the latest version when none is given
+func Lookup(region, version string) (Calculator, error) {
+	rule, err := LookupRule(region, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// LookupRule returns the rule of the region, the latest version when none is given
+func LookupRule(region, version string) (*Rule, error) {
+	versions := rules[region]
+	if len(versions) == 0 {
+		return nil, ErrRuleNotFound
+	}
+	if version == "" {
+		return versions[len(versions)-1], nil
+	}
+
+	for _, rule := range versions {
+		if rule.Version == version {
+			return rule, nil
+		}
+	}
+
+	return nil, ErrRuleNotFound
+}
+
+// Converted returns a copy of the rule with its amounts at the exchange rate,
+// the rule itself when the rate is zero or one
+func (r *Rule) Converted(rate float64) *Rule {
+	if rate == 0 || rate == 1 {
+		return r
+	}
+
+	converted := *r
+	converted.StandardDeduction = r.StandardDeduction * rate
+	converted.Brackets = make([]*Bracket, len(r.Brackets))
+	for i, b := range r.Brackets {
+		converted.Brackets[i] = &Bracket{UpTo: b.UpTo * rate, Rate: b.Rate}
+	}
+	converted.SocialContributions = make([]*Contribution, len(r.SocialContributions))
+	for i, c := range r.SocialContributions {
+		v := *c
+		v.Cap = c.Cap * rate
+		converted.SocialContributions[i] = &v
+	}
+
+	return &converted
+}
+
+// Calculate runs the monthly gross income through the progressive brackets on a yearly basis
+func (r *Rule) Calculate(monthlyGross float64) *model.TaxLineItem {
+	annual := math.Max(monthlyGross, 0) * 12
+
+	var contributions []*model.TaxContribution
+	var totalContributions, deductibleContributions float64
+	for _, c := range r.SocialContributions {
+		base := annual
+		if c.Cap > 0 {
+			base = math.Min(base, c.Cap)
+		}
+
+		amount := base * c.Rate / 100
+		totalContributions += amount
+		if c.Deductible {
+			deductibleContributions += amount
+		}
+
+		contributions = append(contributions, &model.TaxContribution{
+			Name:   c.Name,
+			Rate:   c.Rate,
+			Amount: roundFloat(amount / 12),
+		})
+	}
+
+	deduction := math.Min(r.StandardDeduction+deductibleContributions, annual)
+	taxable := annual - deduction
+
+	var incomeTax, floor float64
+	for _, b := range r.Brackets {
+		if taxable <= floor {
+			break
+		}
+
+		ceil := taxable
+		if b.UpTo > 0 {
+			ceil = math.Min(taxable, b.UpTo)
+		}
+		incomeTax += (ceil - floor) * b.Rate / 100
+		floor = ceil
+	}
+
+	item := &model.TaxLineItem{
+		Region:              r.Region,
+		Version:             r.Version,
+		GrossIncome:         roundFloat(monthlyGross),
+		Deduction:           roundFloat(deduction / 12),
+		TaxableIncome:       roundFloat(taxable / 12),
+		IncomeTax:           roundFloat(incomeTax / 12),
+		SocialContributions: contributions,
+		TotalTax:            roundFloat((incomeTax + totalContributions) / 12),
+	}
+	item.NetIncome = roundFloat(item.GrossIncome - item.TotalTax)
+	if item.GrossIncome > 0 {
+		item.EffectiveRate = roundFloat(item.TotalTax / item.GrossIncome * 100)
+	}
+
+	return item
+}
+
+// mustLoad parses the embedded rule files, they ship with the binary so any error is fatal
+func mustLoad() map[string][]*Rule {
+	loaded := make(map[string][]*Rule)
+
+	err := fs.WalkDir(rulesFS, "rules", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := rulesFS.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rule := new(Rule)
+		if err := json.Unmarshal(data, rule); err != nil {
+			return err
+		}
+		loaded[rule.Region] = append(loaded[rule.Region], rule)
+
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, versions := range loaded {
+		sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	}
+
+	return loaded
+}
+
+func roundFloat(num float64) float64 {
+	return money.RoundFloat(num, money.Cents)
+}
@@ -0,0 +1,101 @@
+package tax
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name            string
+		region, version string
+		rate            float64 // * exchange rate of the rule, unconverted when zero
+		gross           float64
+		deduction       float64
+		taxable         float64
+		incomeTax       float64
+		totalTax        float64
+		net             float64
+		effectiveRate   float64
+	}{
+		{
+			// * 72,000 a year: 57,000 taxable, 1,192.50 + 4,386 + 1,875.50 of income tax,
+			// 4,464 of social security and 1,044 of medicare
+			name: "US 2025", region: "US", version: "2025", gross: 6000,
+			deduction: 1250, taxable: 4750, incomeTax: 621.17, totalTax: 1080.17, net: 4919.83, effectiveRate: 18,
+		},
+		{
+			// * 240,000 a year: 223,900 taxable, 48,104 of income tax up to the 32% bracket,
+			// social security capped at 184,500 gives 11,439 and medicare 3,480
+			name: "US 2026 above the social security cap", region: "US", version: "2026", gross: 20000,
+			deduction: 1341.67, taxable: 18658.33, incomeTax: 4008.67, totalTax: 5251.92, net: 14748.08, effectiveRate: 26.26,
+		},
+		{
+			// * 600,000,000 a year: the insurances are capped at 561,600,000 and deductible, 58,968,000 in total,
+			// 409,032,000 taxable gives 3,000,000 + 6,000,000 + 14,400,000 + 33,600,000 + 6,258,000 of income tax
+			name: "VN 2025", region: "VN", version: "2025", gross: 50000000,
+			deduction: 15914000, taxable: 34086000, incomeTax: 5271500, totalTax: 10185500, net: 39814500, effectiveRate: 20.37,
+		},
+		{
+			// * the same income in dollars at 25,000 dong to the dollar
+			name: "VN 2025 in USD", region: "VN", version: "2025", rate: 1.0 / 25000, gross: 2000,
+			deduction: 636.56, taxable: 1363.44, incomeTax: 210.86, totalTax: 407.42, net: 1592.58, effectiveRate: 20.37,
+		},
+		{
+			name: "no income", region: "US", version: "2026", gross: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := LookupRule(tt.region, tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.rate != 0 {
+				rule = rule.Converted(tt.rate)
+			}
+
+			got := rule.Calculate(tt.gross)
+			if got.Deduction != tt.deduction || got.TaxableIncome != tt.taxable || got.IncomeTax != tt.incomeTax {
+				t.Errorf("deduction, taxable, income tax = %v, %v, %v, want %v, %v, %v",
+					got.Deduction, got.TaxableIncome, got.IncomeTax, tt.deduction, tt.taxable, tt.incomeTax)
+			}
+			if got.TotalTax != tt.totalTax || got.NetIncome != tt.net || got.EffectiveRate != tt.effectiveRate {
+				t.Errorf("total tax, net, effective rate = %v, %v, %v, want %v, %v, %v",
+					got.TotalTax, got.NetIncome, got.EffectiveRate, tt.totalTax, tt.net, tt.effectiveRate)
+			}
+		})
+	}
+}
+
+func TestConvertedKeepsRule(t *testing.T) {
+	rule, err := LookupRule("VN", "2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converted := rule.Converted(1.0 / 25000)
+	if roundFloat(converted.Brackets[0].UpTo) != 2400 || roundFloat(converted.SocialContributions[0].Cap) != 22464 {
+		t.Fatalf("first bracket, cap = %v, %v, want 2400, 22464", converted.Brackets[0].UpTo, converted.SocialContributions[0].Cap)
+	}
+	if rule.Brackets[0].UpTo != 60000000 || rule.SocialContributions[0].Cap != 561600000 {
+		t.Fatalf("the original rule changed: %v, %v", rule.Brackets[0].UpTo, rule.SocialContributions[0].Cap)
+	}
+	if rule.Converted(1) != rule {
+		t.Fatal("rate of one must return the rule itself")
+	}
+}
+
+func TestLookupRule(t *testing.T) {
+	rule, err := LookupRule("US", "")
+	if err != nil || rule.Version != "2026" {
+		t.Fatalf("latest = %+v, %v, want 2026", rule, err)
+	}
+	if _, err := LookupRule("US", "1999"); !errors.Is(err, ErrRuleNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrRuleNotFound)
+	}
+	if _, err := LookupRule("XX", ""); !errors.Is(err, ErrRuleNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrRuleNotFound)
+	}
+}
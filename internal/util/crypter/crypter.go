@@ -2,9 +2,9 @@ package crypter
 
 import (
 	"bytes"
+	"dullahan/internal/money"
 	"encoding/binary"
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/jaevor/go-nanoid"
@@ -35,9 +35,9 @@ func (*Service) UID() string {
 	return UID()
 }
 
-// RoundFloat rounds float64 to 2 decimal places
+// RoundFloat rounds float64 to 2 decimal places with the money rounding mode
 func (s *Service) RoundFloat(f float64) float64 {
-	return toFixedFloat(f, 2)
+	return money.RoundFloat(f, money.Cents)
 }
 
 // Float64ToByte converts float64 to byte
@@ -98,11 +98,6 @@ func generateNanoID() (string, error) {
 	return fmt.Sprintf("%s%s", t.Format(DateLayout), generate()), nil
 }
 
-func toFixedFloat(num float64, precision int) float64 {
-	output := math.Pow(10, float64(precision))
-	return float64(int(num*output)) / output
-}
-
 func float64ToByte(f float64) []byte {
 	var buf bytes.Buffer
 	err := binary.Write(&buf, binary.BigEndian, f)